@@ -0,0 +1,342 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+// Roles of API clients, each role may do everything the roles before it may
+const (
+	RoleReadOnly = "read-only" // read targets, status and notifications
+	RoleOperator = "operator"  // additionally manage single targets and run on-demand checks
+	RoleAdmin    = "admin"     // additionally bulk import targets and send test notifications
+)
+
+var roleLevels = map[string]int{RoleReadOnly: 1, RoleOperator: 2, RoleAdmin: 3}
+
+const (
+	defaultRoleClaim      = "role"
+	defaultNamespaceClaim = "namespace"
+)
+
+// jwtAlgorithms are the signature algorithms accepted for bearer tokens. HMAC is left
+// out, a JWKS file is meant to only contain public keys.
+var jwtAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// AuthConfig enables authentication of API requests. Without it, the API is open to everyone.
+type AuthConfig struct {
+	APIKeys       []APIKeyConfig `json:"apiKeys,omitempty"`
+	JWT           *JWTConfig     `json:"jwt,omitempty"`
+	PublicHealth  bool           `json:"publicHealth,omitempty"`  // GET /health without credentials
+	PublicMetrics bool           `json:"publicMetrics,omitempty"` // GET /metrics without credentials
+	PublicBadges  bool           `json:"publicBadges,omitempty"`  // GET /badge/... without credentials
+	// Roles of clients authenticating with a certificate verified against tls.clientCAFile
+	ClientCertificates []ClientCertConfig `json:"clientCertificates,omitempty"`
+}
+
+// APIKeyConfig is a static key sent in the X-API-Key header
+type APIKeyConfig struct {
+	Name      string `json:"name"` // shown in the logs instead of the key
+	Key       string `json:"key"`
+	Role      string `json:"role"`
+	Namespace string `json:"namespace,omitempty"` // restricts the key to a namespace
+}
+
+// JWTConfig accepts bearer tokens signed by one of the keys of a local JWKS file
+type JWTConfig struct {
+	JWKSFile  string `json:"jwksFile"`
+	Issuer    string `json:"issuer,omitempty"`
+	Audience  string `json:"audience,omitempty"`
+	RoleClaim string `json:"roleClaim,omitempty"` // claim holding the role or a list of roles, defaults to "role"
+	// Claim restricting the token to a namespace, defaults to "namespace"
+	NamespaceClaim string `json:"namespaceClaim,omitempty"`
+}
+
+// Principal is the authenticated client of a request
+type Principal struct {
+	Name      string
+	Role      string
+	Namespace string // the only namespace the client may access, all if empty
+}
+
+type principalKey struct{}
+
+// PrincipalFromContext returns the client a request was authenticated as. There is
+// none if authentication is disabled.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// Authenticator checks the credentials of API requests and the role required by the
+// operation. Its config can be replaced at runtime.
+type Authenticator struct {
+	mu     sync.RWMutex
+	config *AuthConfig
+	keys   []apiKey
+	jwks   *jwksFile
+}
+
+type apiKey struct {
+	name      string
+	role      string
+	namespace string
+	hash      [sha256.Size]byte // compared instead of the key, so the comparison takes the same time for all lengths
+}
+
+// NewAuthenticator creates an Authenticator, authentication is disabled if config is nil
+func NewAuthenticator(config *AuthConfig) (*Authenticator, error) {
+	a := &Authenticator{}
+	if err := a.SetConfig(config); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// SetConfig replaces the API keys and JWT settings. The old ones stay in effect if config is invalid.
+func (a *Authenticator) SetConfig(config *AuthConfig) error {
+	var keys []apiKey
+	var jwks *jwksFile
+	if config != nil {
+		for _, key := range config.APIKeys {
+			keys = append(keys, apiKey{name: key.Name, role: key.Role, namespace: key.Namespace, hash: sha256.Sum256([]byte(key.Key))})
+		}
+		if config.JWT != nil {
+			jwks = &jwksFile{path: config.JWT.JWKSFile}
+			if _, err := jwks.load(); err != nil {
+				return err
+			}
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.config = config
+	a.keys = keys
+	a.jwks = jwks
+	return nil
+}
+
+// Middleware enforces the role the OpenAPI spec requires for an operation
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The generated handlers put the roles allowed by the operation's security requirement into the context
+		scopes, _ := r.Context().Value(ApiKeyScopes).([]string)
+		role := RoleAdmin
+		if len(scopes) > 0 {
+			role = scopes[0]
+		}
+		public := func(config *AuthConfig) bool { return config.PublicHealth && r.Pattern == "GET /health" }
+		a.serve(w, r, next, role, public)
+	})
+}
+
+// Metrics requires the read-only role for next unless the metrics are public. The
+// metrics cover all namespaces, so clients restricted to a namespace can't read them.
+func (a *Authenticator) Metrics(next http.Handler) http.Handler {
+	global := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if principal, ok := PrincipalFromContext(r.Context()); ok && principal.Namespace != "" {
+			respondError(w, r, ErrForbidden(fmt.Sprintf("%s is restricted to the %s namespace", principal.Name, principal.Namespace), nil))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.serve(w, r, global, RoleReadOnly, func(config *AuthConfig) bool { return config.PublicMetrics })
+	})
+}
+
+// Badges requires the read-only role for next unless the badges are public
+func (a *Authenticator) Badges(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.serve(w, r, next, RoleReadOnly, func(config *AuthConfig) bool { return config.PublicBadges })
+	})
+}
+
+func (a *Authenticator) serve(w http.ResponseWriter, r *http.Request, next http.Handler, role string, public func(*AuthConfig) bool) {
+	a.mu.RLock()
+	config := a.config
+	a.mu.RUnlock()
+	if config == nil || public(config) {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	principal, apiErr := a.authenticate(r)
+	if apiErr != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="doctor"`)
+		respondError(w, r, apiErr)
+		return
+	}
+	if roleLevels[principal.Role] < roleLevels[role] {
+		respondError(w, r, ErrForbidden(fmt.Sprintf("%s requires the %s role", principal.Name, role), nil))
+		return
+	}
+
+	next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+}
+
+func (a *Authenticator) authenticate(r *http.Request) (Principal, *ApiError) {
+	a.mu.RLock()
+	keys, jwks, config := a.keys, a.jwks, a.config
+	a.mu.RUnlock()
+
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		hash := sha256.Sum256([]byte(key))
+		for _, k := range keys {
+			if subtle.ConstantTimeCompare(hash[:], k.hash[:]) == 1 {
+				return Principal{Name: k.name, Role: k.role, Namespace: k.namespace}, nil
+			}
+		}
+		return Principal{}, ErrUnauthorized("invalid API key", nil)
+	}
+
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		if jwks == nil {
+			return Principal{}, ErrUnauthorized("bearer tokens are not accepted", nil)
+		}
+		principal, err := verifyJWT(token, jwks, config.JWT)
+		if err != nil {
+			return Principal{}, ErrUnauthorized("invalid bearer token", err)
+		}
+		return principal, nil
+	}
+
+	// The TLS handshake already verified the certificate against the client CAs
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		name := r.TLS.VerifiedChains[0][0].Subject.CommonName
+		for _, cert := range config.ClientCertificates {
+			if cert.CommonName == name {
+				return Principal{Name: name, Role: cert.Role, Namespace: cert.Namespace}, nil
+			}
+		}
+		return Principal{}, ErrUnauthorized(fmt.Sprintf("client certificate %q has no role", name), nil)
+	}
+
+	return Principal{}, ErrUnauthorized("", nil)
+}
+
+func verifyJWT(token string, jwks *jwksFile, config *JWTConfig) (Principal, error) {
+	parsed, err := jwt.ParseSigned(token, jwtAlgorithms)
+	if err != nil {
+		return Principal{}, err
+	}
+	keys, err := jwks.load()
+	if err != nil {
+		return Principal{}, err
+	}
+
+	var claims jwt.Claims
+	var custom map[string]any
+	if err := parsed.Claims(keys, &claims, &custom); err != nil {
+		return Principal{}, err
+	}
+	if claims.Expiry == nil {
+		return Principal{}, fmt.Errorf("token has no expiry")
+	}
+	expected := jwt.Expected{Issuer: config.Issuer, Time: time.Now()}
+	if config.Audience != "" {
+		expected.AnyAudience = jwt.Audience{config.Audience}
+	}
+	if err := claims.ValidateWithLeeway(expected, jwt.DefaultLeeway); err != nil {
+		return Principal{}, err
+	}
+
+	roleClaim := config.RoleClaim
+	if roleClaim == "" {
+		roleClaim = defaultRoleClaim
+	}
+	role := highestRole(custom[roleClaim])
+	if role == "" {
+		return Principal{}, fmt.Errorf("token has no known role in the %q claim", roleClaim)
+	}
+	namespaceClaim := config.NamespaceClaim
+	if namespaceClaim == "" {
+		namespaceClaim = defaultNamespaceClaim
+	}
+	namespace, _ := custom[namespaceClaim].(string)
+	if err := validateNamespace(namespace); err != nil {
+		return Principal{}, err
+	}
+	return Principal{Name: claims.Subject, Role: role, Namespace: namespace}, nil
+}
+
+// highestRole returns the most powerful known role of a role claim, which is either
+// a single role or a list of roles
+func highestRole(claim any) string {
+	var roles []string
+	switch v := claim.(type) {
+	case string:
+		roles = []string{v}
+	case []any:
+		for _, role := range v {
+			if s, ok := role.(string); ok {
+				roles = append(roles, s)
+			}
+		}
+	}
+
+	highest := ""
+	for _, role := range roles {
+		if roleLevels[role] > roleLevels[highest] {
+			highest = role
+		}
+	}
+	return highest
+}
+
+// jwksFile is re-read when it changes, so signing keys can be rotated without a restart
+type jwksFile struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	keys    *jose.JSONWebKeySet
+}
+
+func (f *jwksFile) load() (*jose.JSONWebKeySet, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	if f.keys != nil && info.ModTime().Equal(f.modTime) {
+		return f.keys, nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("invalid JWKS file %s: %w", f.path, err)
+	}
+	if slices.ContainsFunc(keys.Keys, func(key jose.JSONWebKey) bool { return !key.IsPublic() }) {
+		slog.Warn("JWKS file contains private keys, only the public keys are needed", "path", f.path)
+	}
+
+	f.keys = &keys
+	f.modTime = info.ModTime()
+	return f.keys, nil
+}
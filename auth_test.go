@@ -0,0 +1,119 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+func mustAuthenticator(t *testing.T, config *AuthConfig) *Authenticator {
+	t.Helper()
+	auth, err := NewAuthenticator(config)
+	if err != nil {
+		t.Fatalf("Failed to create authenticator: %v", err)
+	}
+	return auth
+}
+
+func TestAuth(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	jwksPath := filepath.Join(t.TempDir(), "jwks.json")
+	jwks, _ := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key.Public(), KeyID: "k1", Algorithm: string(jose.ES256)}}})
+	if err := os.WriteFile(jwksPath, jwks, 0o644); err != nil {
+		t.Fatalf("Failed to write JWKS: %v", err)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, (&jose.SignerOptions{}).WithHeader("kid", "k1"))
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	token := func(claims jwt.Claims, role any) string {
+		raw, err := jwt.Signed(signer).Claims(claims).Claims(map[string]any{"roles": role}).Serialize()
+		if err != nil {
+			t.Fatalf("Failed to sign token: %v", err)
+		}
+		return raw
+	}
+	expiry := jwt.NewNumericDate(time.Now().Add(time.Hour))
+
+	auth := mustAuthenticator(t, &AuthConfig{
+		APIKeys: []APIKeyConfig{
+			{Name: "wallboard", Key: "read-only-key-0123456789", Role: RoleReadOnly},
+			{Name: "deploy", Key: "operator-key-0123456789", Role: RoleOperator},
+		},
+		JWT:          &JWTConfig{JWKSFile: jwksPath, Issuer: "https://sso.example.com", RoleClaim: "roles"},
+		PublicHealth: true,
+	})
+	checker, _ := NewHealthChecker(time.Second, nil)
+	router := http.NewServeMux()
+	api := NewServer(checker, NewHealthMonitor(checker, time.Hour, nil), nil)
+	HandlerWithOptions(api, StdHTTPServerOptions{
+		BaseRouter:  router,
+		Middlewares: []MiddlewareFunc{auth.Middleware},
+	})
+	router.Handle("/metrics", auth.Metrics(http.NotFoundHandler()))
+	router.Handle("GET /badge/", auth.Badges(api.BadgeHandler()))
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	admin := token(jwt.Claims{Subject: "alice", Issuer: "https://sso.example.com", Expiry: expiry}, []string{RoleReadOnly, RoleAdmin})
+	tests := []struct {
+		name, method, path, header, value string
+		status                            int
+	}{
+		{"Public health", "GET", "/health", "", "", http.StatusNoContent},
+		{"Missing credentials", "GET", "/targets", "", "", http.StatusUnauthorized},
+		{"Metrics not public", "GET", "/metrics", "", "", http.StatusUnauthorized},
+		{"Badges not public", "GET", "/badge/example.svg", "", "", http.StatusUnauthorized},
+		{"Read-only badges", "GET", "/badge/label/team/payments.svg", "X-API-Key", "read-only-key-0123456789", http.StatusOK},
+		{"Unknown API key", "GET", "/targets", "X-API-Key", "wrong", http.StatusUnauthorized},
+		{"Read-only reads", "GET", "/targets", "X-API-Key", "read-only-key-0123456789", http.StatusOK},
+		{"Read-only can't write", "DELETE", "/targets/example", "X-API-Key", "read-only-key-0123456789", http.StatusForbidden},
+		{"Operator writes", "DELETE", "/targets/example", "X-API-Key", "operator-key-0123456789", http.StatusNotFound},
+		{"Operator can't bulk import", "POST", "/targets:bulk", "X-API-Key", "operator-key-0123456789", http.StatusForbidden},
+		{"Admin token", "POST", "/targets:bulk", "Authorization", "Bearer " + admin, http.StatusOK},
+		{"Expired token", "GET", "/targets", "Authorization", "Bearer " + token(jwt.Claims{Issuer: "https://sso.example.com", Expiry: jwt.NewNumericDate(time.Now().Add(-time.Hour))}, RoleAdmin), http.StatusUnauthorized},
+		{"Wrong issuer", "GET", "/targets", "Authorization", "Bearer " + token(jwt.Claims{Issuer: "https://evil.example.com", Expiry: expiry}, RoleAdmin), http.StatusUnauthorized},
+		{"Token without expiry", "GET", "/targets", "Authorization", "Bearer " + token(jwt.Claims{Issuer: "https://sso.example.com"}, RoleAdmin), http.StatusUnauthorized},
+		{"Token without role", "GET", "/targets", "Authorization", "Bearer " + token(jwt.Claims{Issuer: "https://sso.example.com", Expiry: expiry}, "superuser"), http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(test.method, server.URL+test.path, strings.NewReader("[]"))
+			if test.header != "" {
+				req.Header.Set(test.header, test.value)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != test.status {
+				t.Fatalf("Expected %d, got %d", test.status, resp.StatusCode)
+			}
+		})
+	}
+
+	t.Run("Disabled by a nil config", func(t *testing.T) {
+		if err := auth.SetConfig(nil); err != nil {
+			t.Fatalf("Failed to disable auth: %v", err)
+		}
+		resp, _ := doRequest(t, "GET", server.URL+"/metrics", "")
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("Expected the metrics handler to be reached, got %d", resp.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Badge colors, the same as shields.io uses
+const (
+	badgeGreen  = "#4c1"
+	badgeOrange = "#fe7d37"
+	badgeRed    = "#e05d44"
+	badgeGray   = "#9f9f9f"
+)
+
+// badge is a shields.io style "label | message" image
+type badge struct {
+	label   string
+	message string
+	color   string
+}
+
+// textWidth approximates the width of s in 11px Verdana, which the badges are rendered
+// in. It only has to be close enough for the text to fit.
+func textWidth(s string) int {
+	width := 0.0
+	for _, c := range s {
+		switch {
+		case strings.ContainsRune("il.,:;|!'I ", c):
+			width += 3.9
+		case strings.ContainsRune("fjrt()[]/-", c):
+			width += 4.9
+		case strings.ContainsRune("mwMW%", c):
+			width += 10.6
+		case c >= 'A' && c <= 'Z':
+			width += 7.6
+		case c >= '0' && c <= '9':
+			width += 7
+		default:
+			width += 6.6
+		}
+	}
+	return int(width + 0.5)
+}
+
+// svg renders the badge
+func (b badge) svg() []byte {
+	label, message := html.EscapeString(b.label), html.EscapeString(b.message)
+	labelWidth := textWidth(b.label) + 10
+	messageWidth := textWidth(b.message) + 10
+	width := labelWidth + messageWidth
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`, width, label, message)
+	fmt.Fprintf(&buf, `<title>%s: %s</title>`, label, message)
+	buf.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`)
+	fmt.Fprintf(&buf, `<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`, width)
+	fmt.Fprintf(&buf, `<g clip-path="url(#r)"><rect width="%d" height="20" fill="#555"/><rect x="%d" width="%d" height="20" fill="%s"/><rect width="%d" height="20" fill="url(#s)"/></g>`,
+		labelWidth, labelWidth, messageWidth, b.color, width)
+	buf.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`)
+	for _, text := range []struct {
+		x    int
+		text string
+	}{{labelWidth / 2, label}, {labelWidth + messageWidth/2, message}} {
+		fmt.Fprintf(&buf, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%d" y="14">%s</text>`, text.x, text.text, text.x, text.text)
+	}
+	buf.WriteString(`</g></svg>`)
+	return buf.Bytes()
+}
+
+// BadgeHandler serves SVG status badges of single targets under /badge/{id}.svg and
+// of all targets with a label value under /badge/label/{key}/{value}.svg
+func (s *Server) BadgeHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /badge/{file}", s.targetBadge)
+	mux.HandleFunc("GET /badge/label/{key}/{file}", s.groupBadge)
+	return mux
+}
+
+// badgeNamespace is requestNamespace that also accepts the namespace as query
+// parameter, badges are embedded as images which can't send headers
+func badgeNamespace(r *http.Request) (string, *ApiError) {
+	if namespace := r.URL.Query().Get("namespace"); namespace != "" && r.Header.Get("X-Namespace") == "" {
+		r = r.Clone(r.Context())
+		r.Header.Set("X-Namespace", namespace)
+	}
+	return requestNamespace(r)
+}
+
+func (s *Server) targetBadge(w http.ResponseWriter, r *http.Request) {
+	id, ok := strings.CutSuffix(r.PathValue("file"), ".svg")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	namespace, apiErr := badgeNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+	metric := r.URL.Query().Get("metric")
+	if metric != "" && metric != "uptime" && metric != "latency" {
+		respondError(w, r, ErrInvalidQuery("metric must be uptime or latency", nil))
+		return
+	}
+
+	b := badge{label: id, message: "unknown", color: badgeGray}
+	if title := r.URL.Query().Get("title"); title != "" {
+		b.label = title
+	}
+	key := targetKey(namespace, id)
+	if _, ok := s.checker.Target(key); !ok {
+		b.message = "not found"
+		serveBadge(w, r, http.StatusNotFound, b)
+		return
+	}
+	if status, ok := s.monitor.Status(key); ok {
+		b.message, b.color = "down", badgeRed
+		if status.Result.Healthy {
+			b.message, b.color = "up", badgeGreen
+		}
+		switch metric {
+		case "uptime":
+			history := s.monitor.History(key)
+			healthy := 0
+			for _, result := range history {
+				if result.Healthy {
+					healthy++
+				}
+			}
+			// Only the latest results are kept, so the window is part of the message
+			if len(history) > 0 {
+				b.message += fmt.Sprintf(" %.1f%% of last %d", float64(healthy)/float64(len(history))*100, len(history))
+			}
+		case "latency":
+			// A failed check's duration is how long it took to fail, not a latency
+			if status.Result.Healthy {
+				b.message += fmt.Sprintf(" %dms", status.Result.Duration.Milliseconds())
+			}
+		}
+	}
+	serveBadge(w, r, http.StatusOK, b)
+}
+
+func (s *Server) groupBadge(w http.ResponseWriter, r *http.Request) {
+	value, ok := strings.CutSuffix(r.PathValue("file"), ".svg")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	namespace, apiErr := badgeNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	b := badge{label: value, message: "no targets", color: badgeGray}
+	if title := r.URL.Query().Get("title"); title != "" {
+		b.label = title
+	}
+	key := r.PathValue("key")
+	checked, healthy := 0, 0
+	for _, target := range s.checker.Targets() {
+		if target.Namespace != namespace || target.Labels[key] != value {
+			continue
+		}
+		b.message = "unknown"
+		if status, ok := s.monitor.Status(target.Key()); ok {
+			checked++
+			if status.Result.Healthy {
+				healthy++
+			}
+		}
+	}
+	if checked > 0 {
+		b.message = fmt.Sprintf("%d/%d up", healthy, checked)
+		switch healthy {
+		case checked:
+			b.color = badgeGreen
+		case 0:
+			b.color = badgeRed
+		default:
+			b.color = badgeOrange
+		}
+	}
+	serveBadge(w, r, http.StatusOK, b)
+}
+
+// serveBadge sends the badge with an ETag of its content, so caches only download it
+// again once the status changed
+func serveBadge(w http.ResponseWriter, r *http.Request, status int, b badge) {
+	svg := b.svg()
+	hash := sha256.Sum256(svg)
+	w.Header().Set("Content-Type", "image/svg+xml;charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("ETag", `"`+hex.EncodeToString(hash[:8])+`"`)
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+		_, _ = w.Write(svg)
+		return
+	}
+	// Answers If-None-Match with 304 Not Modified
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(svg))
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBadges(t *testing.T) {
+	checker, _ := NewHealthChecker(time.Second, nil)
+	notifications, _ := NewNotificationQueue(nil, NotificationConfig{})
+	monitor := NewHealthMonitor(checker, time.Hour, notifications)
+	server := httptest.NewServer(NewServer(checker, monitor, notifications).BadgeHandler())
+	t.Cleanup(server.Close)
+
+	for _, id := range []string{"example", "api", "unchecked"} {
+		target := testResult(true).Target
+		target.ID = id
+		target.Labels = map[string]string{"team": "payments"}
+		if err := checker.AddTarget(context.Background(), target); err != nil {
+			t.Fatalf("Failed to add target: %v", err)
+		}
+	}
+	monitor.processResult(testResult(false))
+	result := testResult(true)
+	result.Target.ID = "api"
+	result.Duration = 120 * time.Millisecond
+	monitor.processResult(result)
+	monitor.processResult(result)
+
+	get := func(path, etag string) (*http.Response, string) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to get %s: %v", path, err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp, string(body)
+	}
+
+	tests := []struct {
+		path    string
+		status  int
+		message string
+		color   string
+	}{
+		{"/badge/example.svg", http.StatusOK, "example: down", badgeRed},
+		{"/badge/api.svg?metric=latency&title=API", http.StatusOK, "API: up 120ms", badgeGreen},
+		{"/badge/api.svg?metric=uptime", http.StatusOK, "api: up 100.0% of last 2", badgeGreen},
+		{"/badge/example.svg?metric=latency", http.StatusOK, "example: down", badgeRed},
+		{"/badge/unchecked.svg", http.StatusOK, "unchecked: unknown", badgeGray},
+		{"/badge/missing.svg", http.StatusNotFound, "missing: not found", badgeGray},
+		{"/badge/label/team/payments.svg", http.StatusOK, "payments: 1/2 up", badgeOrange},
+		{"/badge/label/team/search.svg", http.StatusOK, "search: no targets", badgeGray},
+		{"/badge/example.svg?namespace=team-a", http.StatusNotFound, "example: not found", badgeGray},
+	}
+	for _, test := range tests {
+		resp, body := get(test.path, "")
+		if resp.StatusCode != test.status || resp.Header.Get("Content-Type") != "image/svg+xml;charset=utf-8" {
+			t.Fatalf("Expected %d SVG for %s, got %d %s", test.status, test.path, resp.StatusCode, resp.Header.Get("Content-Type"))
+		}
+		if !strings.Contains(body, "<title>"+test.message+"</title>") || !strings.Contains(body, test.color) {
+			t.Fatalf("Expected %q in %s for %s, got %s", test.message, test.color, test.path, body)
+		}
+	}
+
+	if resp, body := get("/badge/api.svg?metric=requests", ""); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for an unknown metric, got %d: %s", resp.StatusCode, body)
+	}
+
+	// Revalidating with the ETag is answered with 304 until the status changes
+	resp, _ := get("/badge/example.svg", "")
+	etag := resp.Header.Get("ETag")
+	if resp, _ := get("/badge/example.svg", etag); resp.StatusCode != http.StatusNotModified {
+		t.Fatalf("Expected 304 for an unchanged badge, got %d", resp.StatusCode)
+	}
+	monitor.processResult(testResult(true))
+	if resp, body := get("/badge/example.svg", etag); resp.StatusCode != http.StatusOK || !strings.Contains(body, "example: up") {
+		t.Fatalf("Expected the changed badge, got %d: %s", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gitlab.com/tozd/go/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Formats targets can be imported and exported in
+const (
+	TargetFormatJSON = "json"
+	TargetFormatYAML = "yaml"
+	TargetFormatCSV  = "csv"
+)
+
+var targetFormatContentTypes = map[string]string{
+	TargetFormatJSON: "application/json",
+	TargetFormatYAML: "application/yaml",
+	TargetFormatCSV:  "text/csv",
+}
+
+// csvTargetColumns are the columns of an exported CSV file, an import may leave out
+// the optional ones or order them differently
+var csvTargetColumns = []string{"id", "url", "timeoutInSec", "labels"}
+
+// targetFormatForContentType returns the import format for a request content type
+func targetFormatForContentType(contentType string) (string, bool) {
+	if contentType == "" {
+		return TargetFormatJSON, true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+
+	switch mediaType {
+	case "application/json":
+		return TargetFormatJSON, true
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return TargetFormatYAML, true
+	case "text/csv":
+		return TargetFormatCSV, true
+	default:
+		return "", false
+	}
+}
+
+// decodeTargets parses targets in the given format
+func decodeTargets(format string, data []byte) ([]Target, error) {
+	var targets []Target
+	switch format {
+	case TargetFormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&targets); err != nil {
+			return nil, errors.Wrap(err, "invalid JSON")
+		}
+
+	case TargetFormatYAML:
+		// Going through JSON reuses the field names and types of the JSON format
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, errors.Wrap(err, "invalid YAML")
+		}
+		jsonData, err := json.Marshal(doc)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid YAML")
+		}
+		decoder := json.NewDecoder(bytes.NewReader(jsonData))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&targets); err != nil {
+			return nil, errors.Wrap(err, "invalid YAML")
+		}
+
+	case TargetFormatCSV:
+		records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid CSV")
+		}
+		return decodeCSVTargets(records)
+
+	default:
+		return nil, errors.Errorf("unknown format %q", format)
+	}
+	return targets, nil
+}
+
+func decodeCSVTargets(records [][]string) ([]Target, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, column := range records[0] {
+		column = strings.TrimSpace(column)
+		if !slices.Contains(csvTargetColumns, column) {
+			return nil, errors.Errorf("unknown CSV column %q", column)
+		}
+		columns[column] = i
+	}
+	if _, ok := columns["id"]; !ok {
+		return nil, errors.New("CSV header is missing the id column")
+	}
+	if _, ok := columns["url"]; !ok {
+		return nil, errors.New("CSV header is missing the url column")
+	}
+
+	targets := make([]Target, 0, len(records)-1)
+	for line, record := range records[1:] {
+		target := Target{
+			Id:  strings.TrimSpace(record[columns["id"]]),
+			Url: strings.TrimSpace(record[columns["url"]]),
+		}
+		if i, ok := columns["timeoutInSec"]; ok && strings.TrimSpace(record[i]) != "" {
+			timeoutInSec, err := strconv.Atoi(strings.TrimSpace(record[i]))
+			if err != nil {
+				return nil, errors.Errorf("line %d: invalid timeoutInSec %q", line+2, record[i])
+			}
+			target.TimeoutInSec = &timeoutInSec
+		}
+		if i, ok := columns["labels"]; ok {
+			labels, err := parseLabels(record[i])
+			if err != nil {
+				return nil, errors.Errorf("line %d: invalid labels: %w", line+2, err)
+			}
+			if labels != nil {
+				target.Labels = (*Labels)(&labels)
+			}
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// encodeTargets writes targets in the given format, decodeTargets reads it back
+func encodeTargets(format string, targets []Target) ([]byte, error) {
+	// The source and namespace are implied by the import
+	exported := make([]Target, len(targets))
+	for i, target := range targets {
+		target.Source = nil
+		target.Namespace = nil
+		exported[i] = target
+	}
+
+	switch format {
+	case TargetFormatJSON:
+		data, err := json.MarshalIndent(exported, "", "  ")
+		return append(data, '\n'), err
+
+	case TargetFormatYAML:
+		jsonData, err := json.Marshal(exported)
+		if err != nil {
+			return nil, err
+		}
+		var doc any
+		if err := json.Unmarshal(jsonData, &doc); err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(doc)
+
+	case TargetFormatCSV:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		_ = w.Write(csvTargetColumns)
+		for _, target := range exported {
+			timeoutInSec := ""
+			if target.TimeoutInSec != nil {
+				timeoutInSec = strconv.Itoa(*target.TimeoutInSec)
+			}
+			_ = w.Write([]string{target.Id, target.Url, timeoutInSec, formatLabels(derefOrZero(target.Labels))})
+		}
+		w.Flush()
+		return buf.Bytes(), w.Error()
+
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTargetFormats(t *testing.T) {
+	timeoutInSec := 5
+	labels := Labels{"env": "prod", "team": "payments"}
+	targets := []Target{
+		{Id: "example", Url: "https://example.com", TimeoutInSec: &timeoutInSec, Labels: &labels},
+		{Id: "other", Url: "https://other.example.com/health?full=1"},
+	}
+
+	for _, format := range []string{TargetFormatJSON, TargetFormatYAML, TargetFormatCSV} {
+		t.Run(format, func(t *testing.T) {
+			data, err := encodeTargets(format, targets)
+			if err != nil {
+				t.Fatalf("Failed to encode targets: %v", err)
+			}
+			decoded, err := decodeTargets(format, data)
+			if err != nil {
+				t.Fatalf("Failed to decode targets: %v\n%s", err, data)
+			}
+			if !reflect.DeepEqual(decoded, targets) {
+				t.Fatalf("Expected %+v, got %+v", targets, decoded)
+			}
+		})
+	}
+
+	t.Run("CSV columns in any order", func(t *testing.T) {
+		decoded, err := decodeTargets(TargetFormatCSV, []byte("url,id\nhttps://example.com,example\n"))
+		if err != nil {
+			t.Fatalf("Failed to decode targets: %v", err)
+		}
+		if len(decoded) != 1 || decoded[0].Id != "example" || decoded[0].Url != "https://example.com" {
+			t.Fatalf("Unexpected targets: %+v", decoded)
+		}
+	})
+}
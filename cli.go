@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// cliTimeout limits every request of the client subcommands
+const cliTimeout = 30 * time.Second
+
+// listFlag collects the values of a flag that can be repeated
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// clientFlags are the flags of the subcommands that talk to a running Doctor
+type clientFlags struct {
+	server    *string
+	apiKey    *string
+	namespace *string
+	json      *bool
+}
+
+func addClientFlags(flags *flag.FlagSet) clientFlags {
+	server := os.Getenv("DOCTOR_SERVER")
+	if server == "" {
+		server = "http://localhost:8080"
+	}
+	return clientFlags{
+		server:    flags.String("server", server, "URL of the Doctor API, defaults to $DOCTOR_SERVER"),
+		apiKey:    flags.String("api-key", os.Getenv("DOCTOR_API_KEY"), "API key, defaults to $DOCTOR_API_KEY"),
+		namespace: flags.String("namespace", os.Getenv("DOCTOR_NAMESPACE"), "Namespace, defaults to $DOCTOR_NAMESPACE"),
+		json:      flags.Bool("json", false, "Print the API response as JSON"),
+	}
+}
+
+// client creates an API client sending the credentials and namespace with every request
+func (f clientFlags) client() (*ClientWithResponses, error) {
+	return NewClientWithResponses(*f.server,
+		WithHTTPClient(&http.Client{Timeout: cliTimeout}),
+		WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			if *f.apiKey != "" {
+				req.Header.Set("X-API-Key", *f.apiKey)
+			}
+			if *f.namespace != "" {
+				req.Header.Set("X-Namespace", *f.namespace)
+			}
+			return nil
+		}),
+	)
+}
+
+// responseError describes an unexpected response, with the message of the API's error
+// body if there is one
+func responseError(resp *http.Response, body []byte) error {
+	var apiErr Error
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Code != "" {
+		return fmt.Errorf("%s: %s (%s)", resp.Status, apiErr.Message, apiErr.Code)
+	}
+	return fmt.Errorf("%s", resp.Status)
+}
+
+func printJSON(out io.Writer, v any) {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(v)
+}
+
+// targetsCommand lists, adds and removes targets through the API
+func targetsCommand(args []string, out io.Writer) int {
+	const usage = "Usage: doctor targets list|add|rm [flags]"
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+
+	flags := flag.NewFlagSet("targets "+args[0], flag.ExitOnError)
+	client := addClientFlags(flags)
+	var labels listFlag
+	switch args[0] {
+	case "list":
+		flags.Var(&labels, "label", "Only list targets matching the label selector, can be repeated")
+		_ = flags.Parse(args[1:])
+		return listTargets(client, labels, out)
+	case "add":
+		flags.Var(&labels, "label", "Label of the target as key=value, can be repeated")
+		timeout := flags.Int("timeout", 0, "Timeout of a check in seconds, the configured default if 0")
+		flags.Usage = func() {
+			fmt.Fprintln(flags.Output(), "Usage: doctor targets add [flags] <id> <url>")
+			flags.PrintDefaults()
+		}
+		_ = flags.Parse(args[1:])
+		if flags.NArg() != 2 {
+			flags.Usage()
+			return 2
+		}
+		return addTarget(client, flags.Arg(0), flags.Arg(1), labels, *timeout, out)
+	case "rm":
+		flags.Usage = func() {
+			fmt.Fprintln(flags.Output(), "Usage: doctor targets rm [flags] <id>...")
+			flags.PrintDefaults()
+		}
+		_ = flags.Parse(args[1:])
+		if flags.NArg() == 0 {
+			flags.Usage()
+			return 2
+		}
+		return removeTargets(client, flags.Args(), out)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown targets command %q\n%s\n", args[0], usage)
+		return 2
+	}
+}
+
+func listTargets(flags clientFlags, labels listFlag, out io.Writer) int {
+	client, err := flags.client()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	params := &ListTargetsParams{}
+	if len(labels) > 0 {
+		params.Label = (*LabelSelector)(&labels)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cliTimeout)
+	defer cancel()
+	resp, err := client.ListTargetsWithResponse(ctx, params)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if resp.JSON200 == nil {
+		fmt.Fprintln(os.Stderr, responseError(resp.HTTPResponse, resp.Body))
+		return 1
+	}
+
+	if *flags.json {
+		printJSON(out, *resp.JSON200)
+		return 0
+	}
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tURL\tLABELS\tSOURCE")
+	for _, target := range *resp.JSON200 {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", target.Id, target.Url, formatLabels(derefOrZero(target.Labels)), derefOrZero(target.Source))
+	}
+	_ = w.Flush()
+	return 0
+}
+
+func addTarget(flags clientFlags, id, rawURL string, labels listFlag, timeout int, out io.Writer) int {
+	client, err := flags.client()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	target := Target{Id: id, Url: rawURL}
+	if timeout > 0 {
+		target.TimeoutInSec = &timeout
+	}
+	if len(labels) > 0 {
+		parsed, err := parseLabels(strings.Join(labels, ","))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		target.Labels = (*Labels)(&parsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cliTimeout)
+	defer cancel()
+	resp, err := client.CreateTargetWithResponse(ctx, target)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if resp.JSON201 == nil {
+		fmt.Fprintln(os.Stderr, responseError(resp.HTTPResponse, resp.Body))
+		return 1
+	}
+	if *flags.json {
+		printJSON(out, *resp.JSON201)
+	} else {
+		fmt.Fprintf(out, "Added %s\n", resp.JSON201.Id)
+	}
+	return 0
+}
+
+func removeTargets(flags clientFlags, ids []string, out io.Writer) int {
+	client, err := flags.client()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	exitCode := 0
+	for _, id := range ids {
+		ctx, cancel := context.WithTimeout(context.Background(), cliTimeout)
+		resp, err := client.DeleteTargetWithResponse(ctx, id)
+		cancel()
+		switch {
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "%s: %v\n", id, err)
+			exitCode = 1
+		case resp.StatusCode() != http.StatusNoContent:
+			fmt.Fprintf(os.Stderr, "%s: %v\n", id, responseError(resp.HTTPResponse, resp.Body))
+			exitCode = 1
+		default:
+			fmt.Fprintf(out, "Removed %s\n", id)
+		}
+	}
+	return exitCode
+}
+
+// statusCommand prints the latest results and exits with 1 if a target is unhealthy or
+// wasn't checked yet
+func statusCommand(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("status", flag.ExitOnError)
+	clientFlags := addClientFlags(flags)
+	fresh := flags.Bool("fresh", false, "Check all targets now instead of printing the latest results")
+	var labels listFlag
+	flags.Var(&labels, "label", "Only show targets matching the label selector, can be repeated")
+	_ = flags.Parse(args)
+
+	client, err := clientFlags.client()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	params := &GetStatusParams{Fresh: fresh}
+	if len(labels) > 0 {
+		params.Label = (*LabelSelector)(&labels)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cliTimeout)
+	defer cancel()
+	resp, err := client.GetStatusWithResponse(ctx, params)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if resp.JSON200 == nil {
+		fmt.Fprintln(os.Stderr, responseError(resp.HTTPResponse, resp.Body))
+		return 1
+	}
+
+	exitCode := 0
+	for _, result := range *resp.JSON200 {
+		if !result.Healthy || derefOrZero(result.Pending) {
+			exitCode = 1
+		}
+	}
+	if *clientFlags.json {
+		printJSON(out, *resp.JSON200)
+		return exitCode
+	}
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tHEALTH\tSTATUS\tDURATION\tERROR")
+	for _, result := range *resp.JSON200 {
+		health := "healthy"
+		switch {
+		case derefOrZero(result.Pending):
+			health = "pending"
+		case !result.Healthy:
+			health = "unhealthy"
+		}
+		duration := time.Duration(float64(result.DurationSeconds) * float64(time.Second)).Round(time.Millisecond)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", result.Id, health, formatStatusCode(result.Status), duration, derefOrZero(result.Error))
+	}
+	_ = w.Flush()
+	return exitCode
+}
+
+func formatStatusCode(status int) string {
+	if status == 0 {
+		return "-" // the request failed
+	}
+	return fmt.Sprint(status)
+}
+
+// checkCommand checks a URL once with the same probe as the server, without a running
+// Doctor. It exits with 1 if the URL is unhealthy.
+func checkCommand(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	configFile := flags.String("config", "", "Path to config file, to apply its checkTimeoutInSec and egress policy")
+	timeout := flags.Duration("timeout", 0, "Timeout of the check, overrides the config")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: doctor check [flags] <url>")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	checkTimeout, egress := 10*time.Second, (*EgressConfig)(nil)
+	if *configFile != "" {
+		config, err := LoadConfig(*configFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		checkTimeout, egress = time.Duration(config.CheckTimeoutInSec)*time.Second, config.Egress
+	}
+	if *timeout > 0 {
+		checkTimeout = *timeout
+	}
+
+	parsedURL, err := url.Parse(flags.Arg(0))
+	if err == nil {
+		err = validateTargetURL(parsedURL)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid URL: %v\n", err)
+		return 2
+	}
+	checker, err := NewHealthChecker(checkTimeout, nil)
+	if err == nil {
+		err = checker.SetEgress(egress)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	target := HealthTarget{ID: "check", URL: parsedURL, URLString: parsedURL.String()}
+	if apiErr := checker.AddTarget(context.Background(), target); apiErr != nil {
+		fmt.Fprintln(os.Stderr, apiErr)
+		return 2
+	}
+	result, err := checker.CheckTarget(context.Background(), target.Key())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	duration := result.Duration.Round(time.Millisecond)
+	switch {
+	case result.Error != nil:
+		fmt.Fprintf(out, "UNHEALTHY  %s  %s  %v\n", target.URLString, duration, result.Error)
+	case !result.Healthy:
+		fmt.Fprintf(out, "UNHEALTHY  %s  %s  status %d\n", target.URLString, duration, result.Status)
+	default:
+		fmt.Fprintf(out, "HEALTHY    %s  %s  status %d\n", target.URLString, duration, result.Status)
+		return 0
+	}
+	return 1
+}
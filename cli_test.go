@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCLI(t *testing.T) {
+	server, _ := newTestServer(t)
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(healthy.Close)
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(failing.Close)
+
+	run := func(command func([]string, io.Writer) int, args ...string) (int, string) {
+		t.Helper()
+		var out bytes.Buffer
+		return command(args, &out), out.String()
+	}
+
+	steps := []struct {
+		name     string
+		command  func([]string, io.Writer) int
+		args     []string
+		exitCode int
+		output   string
+	}{
+		{"Add", targetsCommand, []string{"add", "-server", server.URL, "-label", "env=prod", "-timeout", "5", "web", healthy.URL}, 0, "Added web"},
+		{"Invalid label", targetsCommand, []string{"add", "-server", server.URL, "-label", "env", "api", healthy.URL}, 2, ""},
+		{"Duplicate", targetsCommand, []string{"add", "-server", server.URL, "web", healthy.URL}, 1, ""},
+		{"List", targetsCommand, []string{"list", "-server", server.URL}, 0, "env=prod"},
+		{"Pending", statusCommand, []string{"-server", server.URL}, 1, "pending"},
+		{"List as JSON", targetsCommand, []string{"list", "-server", server.URL, "-json"}, 0, `"id": "web"`},
+		{"Add failing", targetsCommand, []string{"add", "-server", server.URL, "down", failing.URL}, 0, "Added down"},
+		{"Unhealthy", statusCommand, []string{"-server", server.URL, "-fresh"}, 1, "503"},
+		{"Healthy with label", statusCommand, []string{"-server", server.URL, "-label", "env=prod"}, 0, "web"},
+		{"Remove", targetsCommand, []string{"rm", "-server", server.URL, "down", "missing"}, 1, "Removed down"},
+		{"Unknown subcommand", targetsCommand, []string{"show"}, 2, ""},
+		{"Check healthy", checkCommand, []string{healthy.URL}, 0, "HEALTHY"},
+		{"Check failing", checkCommand, []string{"-timeout", "1s", failing.URL}, 1, "status 503"},
+		{"Check invalid URL", checkCommand, []string{"ftp://example.com"}, 2, ""},
+	}
+	for _, step := range steps {
+		exitCode, output := run(step.command, step.args...)
+		if exitCode != step.exitCode || !strings.Contains(output, step.output) {
+			t.Fatalf("%s: expected exit code %d and %q, got %d: %s", step.name, step.exitCode, step.output, exitCode, output)
+		}
+	}
+}
@@ -1,41 +1,335 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	CheckIntervalInSec int             `json:"checkIntervalInSec"`
-	CheckTimeoutInSec  int             `json:"checkTimeoutInSec"`
-	SMTP               *EmailConfig    `json:"smtp,omitempty"`
-	Telegram           *TelegramConfig `json:"telegram,omitempty"`
-	TargetFile         string          `json:"targetFile,omitempty"`
-	Port               int             `json:"port,omitempty"`
+	Schema             string             `json:"$schema,omitempty"` // Allows referencing configuration-schema.json for editor support
+	CheckIntervalInSec int                `json:"checkIntervalInSec"`
+	CheckTimeoutInSec  int                `json:"checkTimeoutInSec"`
+	SMTP               *EmailConfig       `json:"smtp,omitempty"`
+	Telegram           *TelegramConfig    `json:"telegram,omitempty"`
+	Notifications      NotificationConfig `json:"notifications,omitempty"`
+	Targets            []TargetConfig     `json:"targets,omitempty"`
+	TargetFile         string             `json:"targetFile,omitempty"`
+	TargetStore        *TargetStoreConfig `json:"targetStore,omitempty"` // Takes precedence over targetFile
+	Auth               *AuthConfig        `json:"auth,omitempty"`
+	Namespaces         []NamespaceConfig  `json:"namespaces,omitempty"`
+	Egress             *EgressConfig      `json:"egress,omitempty"`
+	Port               int                `json:"port,omitempty"`
+	TLS                *TLSConfig         `json:"tls,omitempty"`
+	StatusPage         *StatusPageConfig  `json:"statusPage,omitempty"`
+	// Time to wait for in-progress checks and pending notifications on shutdown
+	ShutdownGracePeriodInSec int `json:"shutdownGracePeriodInSec,omitempty"`
+}
+
+// TargetConfig is a target declared in the config file
+type TargetConfig struct {
+	ID           string            `json:"id"`
+	URL          string            `json:"url"`
+	TimeoutInSec int               `json:"timeoutInSec,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
 }
 
+// HealthTargets returns the targets declared in the config, including the ones of all namespaces
+func (c *Config) HealthTargets() ([]HealthTarget, error) {
+	targets, err := healthTargets("", c.Targets)
+	if err != nil {
+		return nil, err
+	}
+	for _, namespace := range c.Namespaces {
+		namespaceTargets, err := healthTargets(namespace.Name, namespace.Targets)
+		if err != nil {
+			return nil, fmt.Errorf("namespace %s: %w", namespace.Name, err)
+		}
+		targets = append(targets, namespaceTargets...)
+	}
+	return targets, nil
+}
+
+func healthTargets(namespace string, configs []TargetConfig) ([]HealthTarget, error) {
+	targets := make([]HealthTarget, 0, len(configs))
+	seen := make(map[string]bool, len(configs))
+	for _, t := range configs {
+		if seen[t.ID] {
+			return nil, fmt.Errorf("duplicate target id %q", t.ID)
+		}
+		seen[t.ID] = true
+		if err := validateTargetID(t.ID); err != nil {
+			return nil, err
+		}
+
+		parsedURL, err := url.Parse(t.URL)
+		if err == nil {
+			err = validateTargetURL(parsedURL)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid url of target %q: %w", t.ID, err)
+		}
+		if err := validateLabels(t.Labels); err != nil {
+			return nil, fmt.Errorf("invalid labels of target %q: %w", t.ID, err)
+		}
+		targets = append(targets, HealthTarget{
+			URL:          parsedURL,
+			URLString:    t.URL,
+			ID:           t.ID,
+			Namespace:    namespace,
+			TimeoutInSec: t.TimeoutInSec,
+			Labels:       t.Labels,
+			Source:       TargetSourceConfig,
+		})
+	}
+	return targets, nil
+}
+
+// LoadConfig reads the config from a JSON, YAML or TOML file, chosen by the file
+// extension, applies DOCTOR_* environment variable overrides, resolves secret
+// references and validates the result against the config schema. If the file
+// doesn't exist, the defaults and environment variables are used.
 func LoadConfig(path string) (*Config, error) {
+	doc, locate, err := readConfigDocument(path)
+	if err != nil {
+		return nil, err
+	}
+	return loadConfigDocument(path, doc, locate)
+}
+
+// loadConfigDocument applies the defaults, overrides and validation of LoadConfig to
+// an already parsed document
+func loadConfigDocument(path string, doc map[string]any, locate configLocator) (*Config, error) {
 	// Default configuration
 	config := &Config{
-		CheckIntervalInSec: 30,
-		CheckTimeoutInSec:  10,
-		Port:               8080,
+		CheckIntervalInSec:       30,
+		CheckTimeoutInSec:        10,
+		Port:                     8080,
+		ShutdownGracePeriodInSec: 30,
 	}
 
-	// Read file
-	data, err := os.ReadFile(path)
+	if err := applyEnvOverrides(doc, reflect.TypeOf(Config{}), envPrefix, os.LookupEnv); err != nil {
+		return nil, fmt.Errorf("error applying environment overrides: %w", err)
+	}
+	if err := resolveSecretReferences(doc); err != nil {
+		return nil, fmt.Errorf("error resolving secret references: %w", err)
+	}
+
+	data, err := json.Marshal(doc)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return config, nil // Return default config if file doesn't exist
-		}
-		return nil, fmt.Errorf("error reading config file: %w", err)
+		return nil, fmt.Errorf("error encoding config: %w", err)
+	}
+
+	if err := validateConfigSchema(data, locate); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
 	}
 
 	// Unmarshal JSON
-	if err := json.Unmarshal(data, config); err != nil {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(config); err != nil {
 		return nil, fmt.Errorf("error parsing config file: %w", err)
 	}
 
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file: %w", err)
+	}
+	if _, err := config.HealthTargets(); err != nil {
+		return nil, fmt.Errorf("invalid config file: %w", err)
+	}
+
 	return config, nil
 }
+
+// configLocator returns the line and column of the field at path in the config file
+type configLocator func(path []string) (line, column int, ok bool)
+
+// readConfigDocument parses the config file into a generic document
+func readConfigDocument(path string) (map[string]any, configLocator, error) {
+	doc := make(map[string]any)
+
+	// Read file
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) || path == "" {
+			return doc, nil, nil // Only defaults and environment variables if the file doesn't exist
+		}
+		return nil, nil, fmt.Errorf("error reading config file: %w", err)
+	}
+	return parseConfigDocument(path, data)
+}
+
+// parseConfigDocument parses the content of the config file at path, the format is
+// chosen by its extension
+func parseConfigDocument(path string, data []byte) (map[string]any, configLocator, error) {
+	doc := make(map[string]any)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return nil, nil, fmt.Errorf("error parsing config file: %w", err)
+		}
+		if node.Kind != 0 { // empty file
+			if err := node.Decode(&doc); err != nil {
+				return nil, nil, fmt.Errorf("error parsing config file: %w", err)
+			}
+		}
+		return doc, func(path []string) (int, int, bool) { return locateYAMLPath(&node, path) }, nil
+
+	case ".toml":
+		if err := toml.Unmarshal(data, &doc); err != nil {
+			return nil, nil, fmt.Errorf("error parsing config file: %w", err)
+		}
+		return doc, nil, nil
+
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&doc); err != nil {
+			return nil, nil, fmt.Errorf("error parsing config file: %w", err)
+		}
+		return doc, func(path []string) (int, int, bool) {
+			offset, ok := locateJSONPath(data, path)
+			if !ok {
+				return 0, 0, false
+			}
+			line := bytes.Count(data[:offset], []byte("\n")) + 1
+			column := offset - bytes.LastIndexByte(data[:offset], '\n')
+			return line, column, true
+		}, nil
+	}
+}
+
+// locateYAMLPath returns the position of the value at path, or of the key for mapping entries
+func locateYAMLPath(node *yaml.Node, path []string) (int, int, bool) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if len(path) == 0 {
+		return node.Line, node.Column, true
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value != path[0] {
+				continue
+			}
+			if len(path) == 1 {
+				return key.Line, key.Column, true
+			}
+			return locateYAMLPath(value, path[1:])
+		}
+	case yaml.SequenceNode:
+		index, err := strconv.Atoi(path[0])
+		if err == nil && index >= 0 && index < len(node.Content) {
+			return locateYAMLPath(node.Content[index], path[1:])
+		}
+	}
+	return 0, 0, false
+}
+
+func (c *Config) validate() error {
+	if c.CheckIntervalInSec <= 0 {
+		return fmt.Errorf("checkIntervalInSec must be positive, got %d", c.CheckIntervalInSec)
+	}
+	if c.CheckTimeoutInSec <= 0 {
+		return fmt.Errorf("checkTimeoutInSec must be positive, got %d", c.CheckTimeoutInSec)
+	}
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535, got %d", c.Port)
+	}
+	if s := c.TargetStore; s != nil {
+		if (s.Type == TargetStoreFile || s.Type == TargetStoreSQLite) && s.Path == "" {
+			return fmt.Errorf("targetStore.path is required for the %s target store", s.Type)
+		}
+		if s.Type == TargetStoreRedis && s.URL == "" {
+			return fmt.Errorf("targetStore.url is required for the redis target store")
+		}
+	}
+	namespaces := make(map[string]bool, len(c.Namespaces))
+	for _, namespace := range c.Namespaces {
+		if namespace.Name == "" {
+			return fmt.Errorf("namespaces: name is required")
+		}
+		if err := validateNamespace(namespace.Name); err != nil {
+			return fmt.Errorf("namespaces: %w", err)
+		}
+		if namespaces[namespace.Name] {
+			return fmt.Errorf("namespaces: %s is declared twice", namespace.Name)
+		}
+		namespaces[namespace.Name] = true
+	}
+	if a := c.Auth; a != nil {
+		keys := make(map[string]bool, len(a.APIKeys))
+		for _, key := range a.APIKeys {
+			if _, ok := roleLevels[key.Role]; !ok {
+				return fmt.Errorf("auth.apiKeys: unknown role %q of key %q", key.Role, key.Name)
+			}
+			if err := validateNamespace(key.Namespace); err != nil {
+				return fmt.Errorf("auth.apiKeys: key %q: %w", key.Name, err)
+			}
+			if keys[key.Key] {
+				return fmt.Errorf("auth.apiKeys: key %q is not unique", key.Name)
+			}
+			keys[key.Key] = true
+		}
+		if a.JWT != nil && a.JWT.JWKSFile == "" {
+			return fmt.Errorf("auth.jwt.jwksFile is required")
+		}
+		for _, cert := range a.ClientCertificates {
+			if _, ok := roleLevels[cert.Role]; !ok {
+				return fmt.Errorf("auth.clientCertificates: unknown role %q of %q", cert.Role, cert.CommonName)
+			}
+			if err := validateNamespace(cert.Namespace); err != nil {
+				return fmt.Errorf("auth.clientCertificates: %q: %w", cert.CommonName, err)
+			}
+		}
+		if len(a.ClientCertificates) > 0 && (c.TLS == nil || c.TLS.ClientCAFile == "") {
+			return fmt.Errorf("auth.clientCertificates requires tls.clientCAFile")
+		}
+	}
+	if _, err := newEgressPolicy(c.Egress); err != nil {
+		return err
+	}
+	if t := c.TLS; t != nil {
+		if t.CertFile == "" || t.KeyFile == "" {
+			return fmt.Errorf("tls.certFile and tls.keyFile are required")
+		}
+		if t.RequireClientCert && t.ClientCAFile == "" {
+			return fmt.Errorf("tls.requireClientCert requires tls.clientCAFile")
+		}
+	}
+	if p := c.StatusPage; p != nil {
+		if p.Port <= 0 || p.Port > 65535 {
+			return fmt.Errorf("statusPage.port must be between 1 and 65535, got %d", p.Port)
+		}
+		if p.Port == c.Port {
+			return fmt.Errorf("statusPage.port must differ from port %d", c.Port)
+		}
+		components := make(map[string]bool, len(p.Components))
+		for _, component := range p.Components {
+			if component.Name == "" {
+				return fmt.Errorf("statusPage.components: name is required")
+			}
+			if components[component.Name] {
+				return fmt.Errorf("statusPage.components: %s is declared twice", component.Name)
+			}
+			components[component.Name] = true
+			if len(component.Targets) == 0 {
+				return fmt.Errorf("statusPage.components: %s has no targets", component.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestConfigSchemaInSync makes sure every Config field is described by
+// configuration-schema.json and the schema doesn't describe fields Config doesn't have
+func TestConfigSchemaInSync(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal(configSchemaJSON, &schema); err != nil {
+		t.Fatalf("Failed to parse schema: %v", err)
+	}
+
+	// resolve follows local references like #/properties/smtp
+	resolve := func(node map[string]any) map[string]any {
+		ref, ok := node["$ref"].(string)
+		if !ok {
+			return node
+		}
+		resolved := schema
+		for _, segment := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			resolved, _ = resolved[segment].(map[string]any)
+		}
+		return resolved
+	}
+
+	var compare func(path string, typ reflect.Type, schema map[string]any)
+	compare = func(path string, typ reflect.Type, schema map[string]any) {
+		schema = resolve(schema)
+		for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map {
+			typ = typ.Elem()
+			if items, ok := schema["items"].(map[string]any); ok {
+				schema = resolve(items)
+			} else if additional, ok := schema["additionalProperties"].(map[string]any); ok {
+				schema = resolve(additional)
+			}
+		}
+		if typ.Kind() != reflect.Struct {
+			return
+		}
+
+		properties, _ := schema["properties"].(map[string]any)
+		if schema["additionalProperties"] != false {
+			t.Errorf("%s: schema must set additionalProperties to false", path)
+		}
+
+		var fields []string
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "-" || name == "" {
+				continue
+			}
+			fields = append(fields, name)
+
+			property, ok := properties[name].(map[string]any)
+			if !ok {
+				t.Errorf("%s/%s: field is missing in the schema", path, name)
+				continue
+			}
+			compare(path+"/"+name, field.Type, property)
+		}
+
+		for name := range properties {
+			if !slices.Contains(fields, name) {
+				t.Errorf("%s/%s: schema property has no field in %s", path, name, typ.Name())
+			}
+		}
+	}
+
+	compare("", reflect.TypeOf(Config{}), schema)
+}
+
+func TestLoadConfigValidation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	content := "{\n    \"checkIntervalInSec\": 0,\n    \"smpt\": {}\n}"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	_, err := LoadConfig(path)
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("Expected a schema error, got %v", err)
+	}
+
+	expected := []SchemaViolation{
+		{Field: "/checkIntervalInSec", Line: 2, Column: 5},
+		{Field: "/smpt", Line: 3, Column: 5},
+	}
+	if len(schemaErr.Violations) != len(expected) {
+		t.Fatalf("Expected %d violations, got %v", len(expected), schemaErr)
+	}
+	for i, want := range expected {
+		got := schemaErr.Violations[i]
+		if got.Field != want.Field || got.Line != want.Line || got.Column != want.Column {
+			t.Errorf("Expected violation at %s line %d column %d, got %s", want.Field, want.Line, want.Column, got)
+		}
+	}
+}
+
+func TestLoadConfigFormats(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		return path
+	}
+
+	t.Run("YAML", func(t *testing.T) {
+		config, err := LoadConfig(write("config.yaml", "checkIntervalInSec: 15\nsmtp:\n  from: alert@company.com\n  smtpHost: mail\n  smtpPort: \"587\"\n  toEmails: [admin@company.com]\n"))
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+		if config.CheckIntervalInSec != 15 || config.SMTP == nil || config.SMTP.ToEmails[0] != "admin@company.com" {
+			t.Fatalf("Unexpected config: %+v", config)
+		}
+	})
+
+	t.Run("YAML violations have line numbers", func(t *testing.T) {
+		_, err := LoadConfig(write("invalid.yml", "checkIntervalInSec: 15\ntelegram:\n  botToken: x\n  chatID: 1\n"))
+		var schemaErr *SchemaError
+		if !errors.As(err, &schemaErr) {
+			t.Fatalf("Expected a schema error, got %v", err)
+		}
+		for _, v := range schemaErr.Violations {
+			if v.Field == "/telegram/chatID" && v.Line == 4 && v.Column == 3 {
+				return
+			}
+		}
+		t.Fatalf("Expected the unknown chatID field on line 4, got %v", schemaErr)
+	})
+
+	t.Run("TOML", func(t *testing.T) {
+		config, err := LoadConfig(write("config.toml", "checkTimeoutInSec = 3\n\n[telegram]\nbotToken = \"x\"\nchatId = 42\n"))
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+		if config.CheckTimeoutInSec != 3 || config.Telegram == nil || config.Telegram.ChatID != 42 {
+			t.Fatalf("Unexpected config: %+v", config)
+		}
+	})
+
+	t.Run("Environment overrides and secret references", func(t *testing.T) {
+		secretFile := write("smtp-password", "from-file\n")
+		t.Setenv("DOCTOR_CHECK_INTERVAL_IN_SEC", "45")
+		t.Setenv("DOCTOR_SMTP_PASSWORD", "${file:"+secretFile+"}")
+		t.Setenv("DOCTOR_SMTP_TO_EMAILS", "a@company.com, b@company.com")
+		t.Setenv("DOCTOR_SMTP_USE_TLS", "true")
+		t.Setenv("TELEGRAM_TOKEN", "from-env")
+		// Lists of objects can't be overridden
+		t.Setenv("DOCTOR_TARGETS", "api")
+		t.Setenv("DOCTOR_TARGETS_0_URL", "https://override.example.com")
+
+		config, err := LoadConfig(write("env.json", `{
+			"checkIntervalInSec": 10,
+			"smtp": {"from": "alert@company.com", "smtpHost": "mail", "smtpPort": "465", "toEmails": ["x@company.com"]},
+			"telegram": {"botToken": "${TELEGRAM_TOKEN}", "chatId": 1},
+			"targets": [{"id": "web", "url": "https://example.com"}],
+			"statusPage": {"port": 8081, "title": "file:/etc/hostname", "components": [{"name": "Website", "targets": ["web"]}]}
+		}`))
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+		if config.CheckIntervalInSec != 45 {
+			t.Errorf("Expected interval override of 45, got %d", config.CheckIntervalInSec)
+		}
+		if config.SMTP.Password != "from-file" || !config.SMTP.UseTLS {
+			t.Errorf("Expected password from file and TLS enabled, got %+v", config.SMTP)
+		}
+		if !slices.Equal(config.SMTP.ToEmails, []string{"a@company.com", "b@company.com"}) {
+			t.Errorf("Expected recipients override, got %v", config.SMTP.ToEmails)
+		}
+		if config.Telegram.BotToken != "from-env" {
+			t.Errorf("Expected bot token from env, got %q", config.Telegram.BotToken)
+		}
+		if len(config.Targets) != 1 || config.Targets[0].URL != "https://example.com" {
+			t.Errorf("Expected the targets of the file, got %+v", config.Targets)
+		}
+		if config.StatusPage.Title != "file:/etc/hostname" {
+			t.Errorf("Expected only ${file:...} to be read from a file, got %q", config.StatusPage.Title)
+		}
+	})
+
+	t.Run("Missing secret file", func(t *testing.T) {
+		_, err := LoadConfig(write("missing-file.json", `{"telegram": {"botToken": "${file:`+filepath.Join(dir, "missing")+`}", "chatId": 1}}`))
+		if err == nil || !strings.Contains(err.Error(), "/telegram/botToken") {
+			t.Fatalf("Expected an error about the missing file, got %v", err)
+		}
+	})
+
+	t.Run("Missing secret reference", func(t *testing.T) {
+		_, err := LoadConfig(write("missing.json", `{"telegram": {"botToken": "${DOCTOR_TEST_UNSET}", "chatId": 1}}`))
+		if err == nil || !strings.Contains(err.Error(), "DOCTOR_TEST_UNSET") {
+			t.Fatalf("Expected an error about the unset variable, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// envPrefix is the prefix of environment variables overriding config fields,
+// e.g. DOCTOR_SMTP_PASSWORD overrides smtp.password
+const envPrefix = "DOCTOR"
+
+// fileReferencePrefix marks a reference to a file instead of an environment variable,
+// e.g. ${file:/run/secrets/password} for a mounted secret
+const fileReferencePrefix = "file:"
+
+var secretReferencePattern = regexp.MustCompile(`\$\{(file:[^}]+|[A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// envName converts a JSON field name to its environment variable segment,
+// e.g. checkIntervalInSec to CHECK_INTERVAL_IN_SEC and useTLS to USE_TLS
+func envName(field string) string {
+	runes := []rune(field)
+	var sb strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				sb.WriteByte('_')
+			}
+		}
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+	return sb.String()
+}
+
+// applyEnvOverrides sets every scalar field of typ that has a matching environment
+// variable in doc. Nested fields are joined with an underscore, lists are comma separated.
+func applyEnvOverrides(doc map[string]any, typ reflect.Type, prefix string, lookup func(string) (string, bool)) error {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "" || name == "-" || strings.HasPrefix(name, "$") {
+			continue
+		}
+		env := prefix + "_" + envName(name)
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		switch {
+		case fieldType.Kind() == reflect.Struct:
+			nested, ok := doc[name].(map[string]any)
+			if !ok {
+				nested = make(map[string]any)
+			}
+			if err := applyEnvOverrides(nested, fieldType, env, lookup); err != nil {
+				return err
+			}
+			if len(nested) > 0 {
+				doc[name] = nested
+			}
+
+		case fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() != reflect.String:
+			// Lists of objects such as targets and apiKeys can only be set in the config
+			// file, their secrets can reference environment variables instead
+
+		default:
+			value, ok := lookup(env)
+			if !ok {
+				continue
+			}
+			parsed, err := parseEnvValue(value, fieldType)
+			if err != nil {
+				return fmt.Errorf("%s: %w", env, err)
+			}
+			doc[name] = parsed
+		}
+	}
+
+	return nil
+}
+
+func parseEnvValue(value string, typ reflect.Type) (any, error) {
+	switch typ.Kind() {
+	case reflect.Bool:
+		return strconv.ParseBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(value, 10, 64)
+	case reflect.Slice:
+		items := make([]any, 0)
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	default:
+		return value, nil
+	}
+}
+
+// resolveSecretReferences replaces ${ENV} references in all strings of doc with the
+// variable's value and ${file:/path} references with the content of the file
+func resolveSecretReferences(doc map[string]any) error {
+	var resolve func(path string, value any) (any, error)
+	resolve = func(path string, value any) (any, error) {
+		switch v := value.(type) {
+		case map[string]any:
+			for key, item := range v {
+				resolved, err := resolve(path+"/"+key, item)
+				if err != nil {
+					return nil, err
+				}
+				v[key] = resolved
+			}
+			return v, nil
+		case []any:
+			for i, item := range v {
+				resolved, err := resolve(fmt.Sprintf("%s/%d", path, i), item)
+				if err != nil {
+					return nil, err
+				}
+				v[i] = resolved
+			}
+			return v, nil
+		case string:
+			return resolveSecretReference(path, v)
+		default:
+			return v, nil
+		}
+	}
+
+	_, err := resolve("", doc)
+	return err
+}
+
+func resolveSecretReference(path, value string) (string, error) {
+	var missing []string
+	var fileErr error
+	resolved := secretReferencePattern.ReplaceAllStringFunc(value, func(ref string) string {
+		name := secretReferencePattern.FindStringSubmatch(ref)[1]
+		if file, ok := strings.CutPrefix(name, fileReferencePrefix); ok {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				fileErr = fmt.Errorf("%s: failed to read referenced file: %w", path, err)
+			}
+			return strings.TrimRight(string(data), "\r\n")
+		}
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return v
+	})
+	if fileErr != nil {
+		return "", fileErr
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%s: referenced environment variable %s is not set", path, strings.Join(missing, ", "))
+	}
+	return resolved, nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"gitlab.com/tozd/go/errors"
+)
+
+// ErrEgressDenied is returned for checks of addresses the egress policy doesn't allow
+var ErrEgressDenied = errors.New("address not allowed by the egress policy")
+
+// EgressConfig restricts the addresses health checks may connect to. Entries are CIDRs,
+// IP addresses, hostnames or wildcards like *.example.com that match all subdomains.
+type EgressConfig struct {
+	Allow []string `json:"allow,omitempty"` // only these may be checked, everything if empty
+	Deny  []string `json:"deny,omitempty"`  // never checked, takes precedence over allow
+}
+
+// defaultDenied are addresses no target should point to. Cloud metadata services live
+// at link-local addresses. Addresses can be checked anyway by listing their IP or CIDR
+// in egress.allow, an allowed hostname doesn't vouch for what it resolves to.
+var defaultDenied = egressRules{
+	prefixes: []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/8"),
+		netip.MustParsePrefix("169.254.0.0/16"),
+		netip.MustParsePrefix("224.0.0.0/4"),
+		netip.MustParsePrefix("::/128"),
+		netip.MustParsePrefix("fe80::/10"),
+		netip.MustParsePrefix("fd00:ec2::254/128"),
+		netip.MustParsePrefix("ff00::/8"),
+	},
+	hosts: []string{"metadata.google.internal"},
+}
+
+type egressRules struct {
+	prefixes []netip.Prefix
+	hosts    []string // lower case, a leading "*." matches all subdomains
+}
+
+func parseEgressRules(entries []string) (egressRules, error) {
+	var rules egressRules
+	for _, entry := range entries {
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			rules.prefixes = append(rules.prefixes, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(entry); err == nil {
+			rules.prefixes = append(rules.prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+		} else if host := strings.TrimSuffix(strings.ToLower(entry), "."); host != "" && !strings.ContainsAny(host, "/:[] ") {
+			rules.hosts = append(rules.hosts, host)
+		} else {
+			return egressRules{}, fmt.Errorf("invalid egress entry %q, expected a CIDR, an IP address or a hostname", entry)
+		}
+	}
+	return rules, nil
+}
+
+func (r egressRules) matchesHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, rule := range r.hosts {
+		if suffix, ok := strings.CutPrefix(rule, "*"); (ok && strings.HasSuffix(host, suffix)) || rule == host {
+			return true
+		}
+	}
+	return false
+}
+
+func (r egressRules) matchesAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range r.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// egressPolicy decides which hosts and addresses health checks may connect to
+type egressPolicy struct {
+	allow, deny egressRules
+}
+
+// newEgressPolicy parses config, a nil config only denies the default addresses
+func newEgressPolicy(config *EgressConfig) (*egressPolicy, error) {
+	if config == nil {
+		return &egressPolicy{}, nil
+	}
+	allow, err := parseEgressRules(config.Allow)
+	if err != nil {
+		return nil, fmt.Errorf("egress.allow: %w", err)
+	}
+	deny, err := parseEgressRules(config.Deny)
+	if err != nil {
+		return nil, fmt.Errorf("egress.deny: %w", err)
+	}
+	return &egressPolicy{allow: allow, deny: deny}, nil
+}
+
+// checkHost checks a hostname or IP literal before it's resolved. Hostnames in the allow
+// list are only decided once their addresses are known.
+func (p *egressPolicy) checkHost(host string) error {
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		return p.checkAddr(host, addr)
+	}
+	if p.deny.matchesHost(host) || (defaultDenied.matchesHost(host) && !p.allow.matchesHost(host)) {
+		return fmt.Errorf("%w: %s", ErrEgressDenied, host)
+	}
+	return nil
+}
+
+// checkAddr checks an address host resolved to
+func (p *egressPolicy) checkAddr(host string, addr netip.Addr) error {
+	if p.deny.matchesHost(host) || p.deny.matchesAddr(addr) {
+		return fmt.Errorf("%w: %s (%s) is denied", ErrEgressDenied, host, addr)
+	}
+	if p.allow.matchesAddr(addr) {
+		return nil
+	}
+	if (len(p.allow.hosts) > 0 || len(p.allow.prefixes) > 0) && !p.allow.matchesHost(host) {
+		return fmt.Errorf("%w: %s (%s) is not allowed", ErrEgressDenied, host, addr)
+	}
+	if defaultDenied.matchesAddr(addr) || (defaultDenied.matchesHost(host) && !p.allow.matchesHost(host)) {
+		return fmt.Errorf("%w: %s (%s) is a link-local, multicast or unspecified address", ErrEgressDenied, host, addr)
+	}
+	return nil
+}
+
+// resolve looks up the addresses of host and checks all of them, so the policy is
+// decided on the addresses that are actually connected to
+func (p *egressPolicy) resolve(ctx context.Context, host string) ([]netip.Addr, error) {
+	if err := p.checkHost(host); err != nil {
+		return nil, err
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", strings.Trim(host, "[]"))
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		if err := p.checkAddr(host, addr); err != nil {
+			return nil, err
+		}
+	}
+	return addrs, nil
+}
+
+// dialContext resolves the host of address itself, so the policy is checked against the
+// addresses that are actually dialed and DNS can't be used to sneak past it
+func (p *egressPolicy) dialContext(ctx context.Context, dialer *net.Dialer, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := p.resolve(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, addr := range addrs {
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(addr.Unmap().String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// proxyAddr returns the host:port the transport dials for proxyURL
+func proxyAddr(proxyURL *url.URL) string {
+	if port := proxyURL.Port(); port != "" {
+		return net.JoinHostPort(proxyURL.Hostname(), port)
+	}
+	switch proxyURL.Scheme {
+	case "https":
+		return net.JoinHostPort(proxyURL.Hostname(), "443")
+	case "socks5", "socks5h":
+		return net.JoinHostPort(proxyURL.Hostname(), "1080")
+	}
+	return net.JoinHostPort(proxyURL.Hostname(), "80")
+}
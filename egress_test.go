@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gitlab.com/tozd/go/errors"
+)
+
+func TestEgressPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *EgressConfig
+		host    string
+		addr    string
+		allowed bool
+	}{
+		{"Public address", nil, "example.com", "93.184.215.14", true},
+		{"Loopback by default", nil, "localhost", "127.0.0.1", true},
+		{"Cloud metadata", nil, "169.254.169.254", "169.254.169.254", false},
+		{"IPv4-mapped metadata", nil, "evil.example.com", "::ffff:169.254.169.254", false},
+		{"Metadata hostname", nil, "metadata.google.internal", "", false},
+		{"Explicitly allowed link-local", &EgressConfig{Allow: []string{"169.254.10.0/24"}}, "device", "169.254.10.1", true},
+		{"Denied CIDR", &EgressConfig{Deny: []string{"10.0.0.0/8"}}, "db.internal", "10.1.2.3", false},
+		{"Denied wildcard", &EgressConfig{Deny: []string{"*.internal"}}, "db.internal", "", false},
+		{"Not in allow list", &EgressConfig{Allow: []string{"*.example.com"}}, "example.org", "93.184.215.15", false},
+		{"Allowed hostname", &EgressConfig{Allow: []string{"*.example.com"}}, "api.example.com", "10.0.0.1", true},
+		{"Allowed hostname resolving to link-local", &EgressConfig{Allow: []string{"*.example.com"}}, "api.example.com", "169.254.169.254", false},
+		{"Allowed hostname and address", &EgressConfig{Allow: []string{"*.example.com", "169.254.10.0/24"}}, "api.example.com", "169.254.10.1", true},
+		{"Deny wins over allow", &EgressConfig{Allow: []string{"10.0.0.0/8"}, Deny: []string{"10.0.0.1"}}, "api", "10.0.0.1", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy, err := newEgressPolicy(test.config)
+			if err != nil {
+				t.Fatalf("Failed to create policy: %v", err)
+			}
+			err = policy.checkHost(test.host)
+			if err == nil && test.addr != "" {
+				err = policy.checkAddr(test.host, netip.MustParseAddr(test.addr))
+			}
+			if allowed := err == nil; allowed != test.allowed {
+				t.Fatalf("Expected allowed to be %t, got %v", test.allowed, err)
+			}
+		})
+	}
+
+	if _, err := newEgressPolicy(&EgressConfig{Deny: []string{"10.0.0.0/33"}}); err == nil {
+		t.Fatalf("Expected an invalid CIDR to be rejected")
+	}
+}
+
+func TestEgressEnforcedWhenConnecting(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(target.Close)
+
+	checker, _ := NewHealthChecker(time.Second, nil)
+	if err := checker.SetEgress(&EgressConfig{Deny: []string{"127.0.0.0/8", "::1"}}); err != nil {
+		t.Fatalf("Failed to set egress policy: %v", err)
+	}
+	// localhost only resolves to a denied address when connecting
+	u, _ := url.Parse("http://localhost:" + target.URL[len("http://127.0.0.1:"):])
+	if err := checker.CheckEgress(u); err != nil {
+		t.Fatalf("Expected the hostname to pass before it's resolved, got %v", err)
+	}
+	if err := checker.AddTarget(context.Background(), HealthTarget{ID: "local", URL: u, URLString: u.String()}); err != nil {
+		t.Fatalf("Failed to add target: %v", err)
+	}
+
+	result, err := checker.CheckTarget(context.Background(), "local")
+	if err != nil {
+		t.Fatalf("Failed to check target: %v", err)
+	}
+	if !errors.Is(result.Error, ErrEgressDenied) {
+		t.Fatalf("Expected the check to be denied, got %v", result.Error)
+	}
+}
+
+func TestEgressEnforcedThroughProxy(t *testing.T) {
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Add(1)
+	}))
+	t.Cleanup(proxy.Close)
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	checker, _ := NewHealthChecker(time.Second, nil)
+	checker.proxy = http.ProxyURL(proxyURL)
+	// The proxy itself is denied, but the policy applies to the targets behind it
+	if err := checker.SetEgress(&EgressConfig{Deny: []string{"127.0.0.0/8", "192.0.2.2"}}); err != nil {
+		t.Fatalf("Failed to set egress policy: %v", err)
+	}
+	for _, rawURL := range []string{"http://192.0.2.1/health", "http://192.0.2.2/health"} {
+		u, _ := url.Parse(rawURL)
+		if err := checker.AddTarget(context.Background(), HealthTarget{ID: u.Hostname(), URL: u, URLString: rawURL}); err != nil {
+			t.Fatalf("Failed to add target: %v", err)
+		}
+	}
+
+	if result, err := checker.CheckTarget(context.Background(), "192.0.2.1"); err != nil || !result.Healthy {
+		t.Fatalf("Expected the allowed target to be checked through the proxy, got %+v, %v", result, err)
+	}
+	if result, err := checker.CheckTarget(context.Background(), "192.0.2.2"); err != nil || !errors.Is(result.Error, ErrEgressDenied) {
+		t.Fatalf("Expected the denied target to be rejected, got %+v, %v", result, err)
+	}
+	if proxied.Load() != 1 {
+		t.Fatalf("Expected only the allowed target to reach the proxy, got %d requests", proxied.Load())
+	}
+}
@@ -45,6 +45,30 @@ var (
 	ErrParseJsonBody  = apiErrorFactory(http.StatusBadRequest, "parse_json_body", "Error parsing JSON body")
 	ErrEncodeJsonBody = apiErrorFactory(http.StatusInternalServerError, "encode_json_body", "Error encoding JSON body")
 	ErrInvalidUrl     = apiErrorFactory(http.StatusBadRequest, "invalid_url", "Invalid URL")
+	ErrUrlNotAllowed  = apiErrorFactory(http.StatusBadRequest, "url_not_allowed", "The URL points to an address Doctor may not check")
 	ErrAddingTarget   = apiErrorFactory(http.StatusInternalServerError, "adding_target", "Error adding target")
 	ErrRemovingTarget = apiErrorFactory(http.StatusInternalServerError, "removing_target", "Error removing target")
+	ErrUnknownChannel = apiErrorFactory(http.StatusNotFound, "unknown_channel", "Notification channel is not configured")
+	ErrTargetReadOnly = apiErrorFactory(http.StatusForbidden, "target_read_only", "Target is declared in the config file and can't be changed through the API")
+	ErrUnknownTarget  = apiErrorFactory(http.StatusNotFound, "target_not_found", "Target not found")
+	ErrTargetExists   = apiErrorFactory(http.StatusConflict, "target_exists", "A target with this ID is already registered")
+	ErrInvalidTarget  = apiErrorFactory(http.StatusBadRequest, "invalid_target", "Invalid target")
+	ErrParseBody      = apiErrorFactory(http.StatusBadRequest, "parse_body", "Error parsing request body")
+	ErrInvalidFormat  = apiErrorFactory(http.StatusBadRequest, "invalid_format", "Unknown format")
+	ErrInvalidQuery   = apiErrorFactory(http.StatusBadRequest, "invalid_query", "Invalid query parameter")
+	ErrInvalidSilence = apiErrorFactory(http.StatusBadRequest, "invalid_silence", "Invalid silence")
+	ErrUnknownSilence = apiErrorFactory(http.StatusNotFound, "silence_not_found", "Silence not found")
+
+	ErrNoStatusPage              = apiErrorFactory(http.StatusNotFound, "status_page_not_configured", "No status page is configured")
+	ErrUnknownStatusPageIncident = apiErrorFactory(http.StatusNotFound, "status_page_incident_not_found", "Status page incident not found")
+	ErrInvalidStatusPageUpdate   = apiErrorFactory(http.StatusBadRequest, "invalid_status_page_update", "Invalid status page update")
+
+	ErrInvalidNamespace = apiErrorFactory(http.StatusBadRequest, "invalid_namespace", "Invalid namespace")
+
+	ErrUnauthorized = apiErrorFactory(http.StatusUnauthorized, "unauthorized", "Missing credentials, send an X-API-Key header or a bearer token")
+	ErrForbidden    = apiErrorFactory(http.StatusForbidden, "forbidden", "The credentials don't have the role required for this operation")
+
+	ErrTooManyRequests = apiErrorFactory(http.StatusTooManyRequests, "too_many_requests", "Too many on-demand checks, try again later")
+
+	ErrUnsupportedMediaType = apiErrorFactory(http.StatusUnsupportedMediaType, "unsupported_media_type", "Unsupported content type")
 )
@@ -0,0 +1,109 @@
+package main
+
+import "sync"
+
+// Incident changes of incident events
+const (
+	IncidentOpened   = "opened"   // the target failed twice in a row and an alert was sent
+	IncidentResolved = "resolved" // the target recovered after an alert
+	IncidentRemoved  = "removed"  // the target was removed while its incident was open
+)
+
+// subscriptionBuffer is the number of events a subscriber may fall behind before it's dropped
+const subscriptionBuffer = 256
+
+// Event is a change of a target's health published by the HealthMonitor
+type Event struct {
+	Type     EventType
+	Status   TargetStatus // status of the target after the change
+	Incident string       // IncidentOpened, IncidentResolved or IncidentRemoved for incident events
+}
+
+// EventBroker fans events out to subscribers. Subscribers that can't keep up are
+// dropped instead of delaying the checks.
+type EventBroker struct {
+	mu          sync.Mutex
+	subscribers map[*Subscription]bool
+	closed      bool
+}
+
+// Subscription receives the events matching its filter until it's closed. Events is
+// closed if the subscriber fell behind or the broker was closed.
+type Subscription struct {
+	Events <-chan Event
+	events chan Event
+	filter func(Event) bool
+	broker *EventBroker
+	// dropped is set if the subscriber fell behind, guarded by broker.mu
+	dropped bool
+}
+
+// NewEventBroker creates an EventBroker without subscribers
+func NewEventBroker() *EventBroker {
+	return &EventBroker{subscribers: make(map[*Subscription]bool)}
+}
+
+// Subscribe returns a subscription to the events filter returns true for
+func (b *EventBroker) Subscribe(filter func(Event) bool) *Subscription {
+	events := make(chan Event, subscriptionBuffer)
+	sub := &Subscription{Events: events, events: events, filter: filter, broker: b}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(events)
+	} else {
+		b.subscribers[sub] = true
+	}
+	return sub
+}
+
+// Close stops the subscription, it's safe to call it several times
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s)
+}
+
+// Dropped reports whether Events was closed because the subscriber fell behind, as
+// opposed to the subscription or the broker being closed
+func (s *Subscription) Dropped() bool {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	return s.dropped
+}
+
+// Publish sends event to all matching subscribers without blocking
+func (b *EventBroker) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers {
+		if !sub.filter(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			sub.dropped = true
+			b.remove(sub)
+		}
+	}
+}
+
+// Close ends all subscriptions, e.g. so streaming requests finish on shutdown
+func (b *EventBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for sub := range b.subscribers {
+		b.remove(sub)
+	}
+}
+
+// remove must be called with b.mu held
+func (b *EventBroker) remove(sub *Subscription) {
+	if b.subscribers[sub] {
+		delete(b.subscribers, sub)
+		close(sub.events)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventBroker(t *testing.T) {
+	broker := NewEventBroker()
+	all := broker.Subscribe(func(Event) bool { return true })
+	incidents := broker.Subscribe(func(event Event) bool { return event.Type == EventIncident })
+
+	for range subscriptionBuffer + 1 {
+		broker.Publish(Event{Type: EventCheck})
+	}
+	broker.Publish(Event{Type: EventIncident, Incident: IncidentOpened})
+
+	// The subscriber that fell behind is dropped once its buffer is drained
+	received := 0
+	for range all.Events {
+		received++
+	}
+	if received != subscriptionBuffer {
+		t.Fatalf("Expected %d buffered events, got %d", subscriptionBuffer, received)
+	}
+	if event := <-incidents.Events; event.Incident != IncidentOpened {
+		t.Fatalf("Expected the incident, got %+v", event)
+	}
+
+	broker.Close()
+	if _, ok := <-incidents.Events; ok {
+		t.Fatalf("Expected the subscription to be closed")
+	}
+	incidents.Close()
+}
+
+func TestStreamEvents(t *testing.T) {
+	checker, _ := NewHealthChecker(time.Second, nil)
+	notifications, _ := NewNotificationQueue(nil, NotificationConfig{})
+	monitor := NewHealthMonitor(checker, time.Hour, notifications)
+	router := http.NewServeMux()
+	HandlerFromMux(NewServer(checker, monitor, notifications), router)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	// Known before the stream starts, so it's sent as the initial state
+	if err := checker.AddTarget(context.Background(), testResult(true).Target); err != nil {
+		t.Fatalf("Failed to add target: %v", err)
+	}
+	monitor.processResult(testResult(true))
+
+	resp, err := http.Get(server.URL + "/events?type=state&type=incident&target=example")
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("Expected an event stream, got %s", contentType)
+	}
+	lines := bufio.NewScanner(resp.Body)
+	next := func() (string, string) {
+		t.Helper()
+		var event, data string
+		for lines.Scan() && lines.Text() != "" {
+			if value, ok := strings.CutPrefix(lines.Text(), "event: "); ok {
+				event = value
+			} else if value, ok := strings.CutPrefix(lines.Text(), "data: "); ok {
+				data = value
+			}
+		}
+		return event, data
+	}
+
+	if event, data := next(); event != "state" || !strings.Contains(data, `"healthy":true`) {
+		t.Fatalf("Expected the initial healthy state, got %s %s", event, data)
+	}
+
+	other := testResult(false)
+	other.Target.ID = "other"
+	monitor.processResult(other)             // filtered by target
+	monitor.processResult(testResult(false)) // only a check event, no change
+	monitor.processResult(testResult(false))
+	monitor.processResult(testResult(true))
+
+	expected := []struct{ event, data string }{
+		{"state", `"healthy":false`},
+		{"incident", `"incident":"opened"`},
+		{"state", `"healthy":true`},
+		{"incident", `"incident":"resolved"`},
+	}
+	for _, e := range expected {
+		if event, data := next(); event != e.event || !strings.Contains(data, e.data) || !strings.Contains(data, `"id":"example"`) {
+			t.Fatalf("Expected %s event with %s, got %s %s", e.event, e.data, event, data)
+		}
+	}
+}
@@ -1,12 +1,18 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"log/slog"
+	"maps"
+	"net"
 	"net/http"
 	"net/url"
-	"os"
+	"regexp"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"gitlab.com/tozd/go/errors"
@@ -14,13 +20,46 @@ import (
 
 var (
 	ErrTargetNotFound = errors.New("target not found")
+	ErrTargetRemoved  = errors.New("target was removed")
+)
+
+// Target IDs are used in URL paths and as part of the target keys, so they can't contain slashes
+var targetIDRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.\-]{0,127}$`)
+
+// Sources a target can be registered from
+const (
+	TargetSourceConfig = "config" // declared in the config file, read-only through the API
+	TargetSourceAPI    = "api"    // registered through the API and persisted to the target file
 )
 
 // HealthTarget represents a URL to be monitored
 type HealthTarget struct {
-	URL       *url.URL `json:"-"`
-	URLString string   `json:"url"`
-	ID        string   `json:"id"`
+	URL          *url.URL          `json:"-"`
+	URLString    string            `json:"url"`
+	ID           string            `json:"id"`
+	Namespace    string            `json:"namespace,omitempty"`
+	TimeoutInSec int               `json:"timeoutInSec,omitempty"` // Overrides the default check timeout
+	Labels       map[string]string `json:"labels,omitempty"`
+	Source       string            `json:"source,omitempty"`
+}
+
+// validateTargetID checks that id is usable in URL paths and target keys
+func validateTargetID(id string) error {
+	if id == "" {
+		return fmt.Errorf("id must not be empty")
+	}
+	if !targetIDRegexp.MatchString(id) {
+		return fmt.Errorf("invalid id %q, it must start with a letter or digit, consist of letters, digits, '_', '.' and '-' and be at most 128 characters long", id)
+	}
+	return nil
+}
+
+// validateTargetURL checks that u is an absolute http or https URL
+func validateTargetURL(u *url.URL) error {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL")
+	}
+	return nil
 }
 
 // Result represents the health check result
@@ -36,89 +75,490 @@ type Result struct {
 // HealthChecker manages the health checking process
 type HealthChecker struct {
 	client    *http.Client
+	timeout   atomic.Int64 // default check timeout as time.Duration
+	egress    atomic.Pointer[egressPolicy]
+	proxy     func(*http.Request) (*url.URL, error)
+	proxies   sync.Map // addresses of the proxies in use, dialed without the egress policy
 	mu        sync.RWMutex
 	targets   map[string]HealthTarget
-	storePath string
+	lifetimes map[string]targetLifetime
+	store     TargetStore
+	invalid   map[string]bool // keys of stored targets that are ignored, so they're only logged once
+	// generation counts the writes to the store, so Sync notices writes while it lists
+	generation uint64
 }
 
-// NewHealthChecker creates a new HealthChecker instance
-func NewHealthChecker(timeout time.Duration, storePath string) (*HealthChecker, error) {
+// targetLifetime is cancelled once its target is removed or replaced,
+// which cancels all checks in flight for it
+type targetLifetime struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+}
+
+// NewHealthChecker creates a new HealthChecker instance. If store is nil, targets
+// registered through the API are not persisted.
+func NewHealthChecker(timeout time.Duration, store TargetStore) (*HealthChecker, error) {
 	hc := &HealthChecker{
 		targets:   make(map[string]HealthTarget),
-		client:    &http.Client{Timeout: timeout},
-		storePath: storePath,
+		lifetimes: make(map[string]targetLifetime),
+		store:     store,
+		invalid:   make(map[string]bool),
 	}
-
-	if storePath != "" {
-		if err := hc.loadTargets(); err != nil {
-			return nil, errors.Wrap(err, "failed to load targets")
+	hc.SetTimeout(timeout)
+	_ = hc.SetEgress(nil)
+
+	// Checks through a proxy from HTTP_PROXY and HTTPS_PROXY resolve the target's host
+	// themselves to apply the egress policy, since only the proxy connects to the target
+	hc.proxy = http.ProxyFromEnvironment
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		proxyURL, err := hc.proxy(req)
+		if err != nil || proxyURL == nil {
+			return proxyURL, err
+		}
+		if _, err := hc.egress.Load().resolve(req.Context(), req.URL.Hostname()); err != nil {
+			return nil, err
+		}
+		hc.proxies.Store(proxyAddr(proxyURL), true)
+		return proxyURL, nil
+	}
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		if _, ok := hc.proxies.Load(address); ok {
+			return dialer.DialContext(ctx, network, address)
 		}
+		return hc.egress.Load().dialContext(ctx, dialer, network, address)
+	}
+	hc.client = &http.Client{Transport: transport}
+
+	if err := hc.Sync(context.Background()); err != nil {
+		return nil, errors.Wrap(err, "failed to load targets")
 	}
 
 	return hc, nil
 }
 
-// AddTarget adds a new target to the health checker
-func (hc *HealthChecker) AddTarget(target HealthTarget) *ApiError {
+// SetTimeout changes the default timeout of checks started afterwards
+func (hc *HealthChecker) SetTimeout(timeout time.Duration) {
+	hc.timeout.Store(int64(timeout))
+}
+
+// SetEgress replaces the policy of the addresses checks may connect to. The old policy
+// stays in effect if config is invalid.
+func (hc *HealthChecker) SetEgress(config *EgressConfig) error {
+	policy, err := newEgressPolicy(config)
+	if err != nil {
+		return err
+	}
+	hc.egress.Store(policy)
+	return nil
+}
+
+// CheckEgress rejects URLs whose host the egress policy denies without resolving it.
+// Checks still verify the resolved addresses when they connect.
+func (hc *HealthChecker) CheckEgress(u *url.URL) error {
+	return hc.egress.Load().checkHost(u.Hostname())
+}
+
+// AddTarget registers a new target, it fails if the ID is already in use in its namespace
+func (hc *HealthChecker) AddTarget(ctx context.Context, target HealthTarget) *ApiError {
 	hc.mu.Lock()
 	defer hc.mu.Unlock()
-	hc.targets[target.ID] = target
-	registeredTargets.Inc()
+	if existing, ok := hc.targets[target.Key()]; ok {
+		if existing.Source == TargetSourceConfig {
+			return ErrTargetReadOnly("", nil)
+		}
+		return ErrTargetExists("", nil)
+	}
 
-	if hc.storePath == "" {
-		return nil
+	if err := hc.storeTarget(ctx, target); err != nil {
+		return ErrAddingTarget("failed to persist target", err)
 	}
+	return nil
+}
 
-	if err := hc.saveTargets(); err != nil {
-		return ErrAddingTarget("failed to persist targets", err)
+// PutTarget adds the target or replaces the one with the same ID in its namespace.
+// created reports whether the target didn't exist before.
+func (hc *HealthChecker) PutTarget(ctx context.Context, target HealthTarget) (created bool, apiErr *ApiError) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	existing, ok := hc.targets[target.Key()]
+	if ok && existing.Source == TargetSourceConfig {
+		return false, ErrTargetReadOnly("", nil)
+	}
+
+	if err := hc.storeTarget(ctx, target); err != nil {
+		return false, ErrAddingTarget("failed to persist target", err)
 	}
+	return !ok, nil
+}
 
+// UpdateTarget changes the target with the given key through update and stores the result
+func (hc *HealthChecker) UpdateTarget(ctx context.Context, key string, update func(target *HealthTarget) *ApiError) (HealthTarget, *ApiError) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	target, ok := hc.targets[key]
+	if !ok {
+		return HealthTarget{}, ErrUnknownTarget("", nil)
+	}
+	if target.Source == TargetSourceConfig {
+		return HealthTarget{}, ErrTargetReadOnly("", nil)
+	}
+
+	id, namespace := target.ID, target.Namespace
+	if apiErr := update(&target); apiErr != nil {
+		return HealthTarget{}, apiErr
+	}
+	target.ID, target.Namespace = id, namespace
+	if err := hc.storeTarget(ctx, target); err != nil {
+		return HealthTarget{}, ErrAddingTarget("failed to persist target", err)
+	}
+	return hc.targets[key], nil
+}
+
+// RemoveTarget removes the target with the given key from the health checker
+func (hc *HealthChecker) RemoveTarget(ctx context.Context, key string) *ApiError {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	existing, ok := hc.targets[key]
+	if !ok {
+		return ErrUnknownTarget("", nil)
+	}
+	if existing.Source == TargetSourceConfig {
+		return ErrTargetReadOnly("", nil)
+	}
+
+	if hc.store != nil {
+		hc.generation++
+		if err := hc.store.Delete(ctx, key); err != nil {
+			return ErrRemovingTarget("failed to persist target removal", err)
+		}
+	}
+
+	hc.deleteTarget(key)
+	hc.countTargets()
 	return nil
 }
 
-// RemoveTarget removes a target from the health checker
-func (hc *HealthChecker) RemoveTarget(id string) *ApiError {
+// TargetDiff lists the IDs of the targets changed by ApplyTargets
+type TargetDiff struct {
+	Created   []string
+	Updated   []string
+	Deleted   []string
+	Unchanged []string
+}
+
+// ApplyTargets adds or replaces all targets of namespace with a single write to the
+// store. With replace set, targets of the namespace registered through the API that
+// are not in targets are removed. With dryRun set, only the diff is computed.
+func (hc *HealthChecker) ApplyTargets(ctx context.Context, namespace string, targets []HealthTarget, replace, dryRun bool) (TargetDiff, *ApiError) {
 	hc.mu.Lock()
 	defer hc.mu.Unlock()
-	delete(hc.targets, id)
-	registeredTargets.Dec()
 
-	if hc.storePath == "" {
-		return nil
+	var diff TargetDiff
+	var put []HealthTarget
+	imported := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		if imported[target.ID] {
+			return TargetDiff{}, ErrInvalidTarget(fmt.Sprintf("duplicate target id %q", target.ID), nil)
+		}
+		imported[target.ID] = true
+		target.Namespace = namespace
+		target.Source = TargetSourceAPI
+
+		existing, ok := hc.targets[target.Key()]
+		switch {
+		case ok && existing.Source == TargetSourceConfig:
+			return TargetDiff{}, ErrTargetReadOnly(fmt.Sprintf("target %q is declared in the config file", target.ID), nil)
+		case !ok:
+			diff.Created = append(diff.Created, target.ID)
+		case sameTarget(existing, target):
+			diff.Unchanged = append(diff.Unchanged, target.ID)
+			continue
+		default:
+			diff.Updated = append(diff.Updated, target.ID)
+		}
+		put = append(put, target)
+	}
+
+	if replace {
+		for _, target := range hc.targets {
+			if target.Namespace == namespace && target.Source == TargetSourceAPI && !imported[target.ID] {
+				diff.Deleted = append(diff.Deleted, target.ID)
+			}
+		}
+	}
+
+	for _, ids := range [][]string{diff.Created, diff.Updated, diff.Deleted, diff.Unchanged} {
+		slices.Sort(ids)
+	}
+	if dryRun || (len(put) == 0 && len(diff.Deleted) == 0) {
+		return diff, nil
+	}
+
+	remove := make([]string, len(diff.Deleted))
+	for i, id := range diff.Deleted {
+		remove[i] = targetKey(namespace, id)
+	}
+	if hc.store != nil {
+		hc.generation++
+		if err := hc.store.Apply(ctx, put, remove); err != nil {
+			return TargetDiff{}, ErrAddingTarget("failed to persist targets", err)
+		}
+	}
+	for _, target := range put {
+		hc.setTarget(target)
+	}
+	for _, key := range remove {
+		hc.deleteTarget(key)
+	}
+	hc.countTargets()
+
+	return diff, nil
+}
+
+// Target returns the target with the given key
+func (hc *HealthChecker) Target(key string) (HealthTarget, bool) {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	target, ok := hc.targets[key]
+	return target, ok
+}
+
+// Targets returns the targets of all namespaces ordered by key
+func (hc *HealthChecker) Targets() []HealthTarget {
+	hc.mu.RLock()
+	targets := MapValues(hc.targets)
+	hc.mu.RUnlock()
+
+	slices.SortFunc(targets, func(a, b HealthTarget) int { return strings.Compare(a.Key(), b.Key()) })
+	return targets
+}
+
+// NamespaceTargets returns the targets of a namespace ordered by ID
+func (hc *HealthChecker) NamespaceTargets(namespace string) []HealthTarget {
+	var targets []HealthTarget
+	for _, target := range hc.Targets() {
+		if target.Namespace == namespace {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// countTargets updates the registered targets metric, it must be called with hc.mu held
+func (hc *HealthChecker) countTargets() {
+	counts := make(map[string]int)
+	for _, target := range hc.targets {
+		counts[target.Namespace]++
+	}
+	registeredTargets.Reset()
+	for namespace, count := range counts {
+		registeredTargets.WithLabelValues(namespace).Set(float64(count))
 	}
+}
 
-	if err := hc.saveTargets(); err != nil {
-		return ErrRemovingTarget("failed to persist targets", err)
+// storeTarget persists a target registered through the API and starts checking it.
+// It must be called with hc.mu held.
+func (hc *HealthChecker) storeTarget(ctx context.Context, target HealthTarget) error {
+	target.Source = TargetSourceAPI
+	if hc.store != nil {
+		hc.generation++
+		if err := hc.store.Put(ctx, target); err != nil {
+			return err
+		}
 	}
 
+	hc.setTarget(target)
+	hc.countTargets()
 	return nil
 }
 
-// checkHealth performs the health check for a single target
-func (hc *HealthChecker) checkHealth(target HealthTarget) Result {
+// syncAttempts is how often Sync lists the store if targets are written meanwhile
+const syncAttempts = 3
+
+// Sync replaces the targets registered through the API with the ones in the store,
+// picking up changes made by other replicas sharing it
+func (hc *HealthChecker) Sync(ctx context.Context) error {
+	if hc.store == nil {
+		return nil
+	}
+
+	for attempt := 1; ; attempt++ {
+		hc.mu.RLock()
+		generation := hc.generation
+		hc.mu.RUnlock()
+
+		// Listed without the lock, so a slow store doesn't hold up checks and API requests
+		stored, err := hc.store.List(ctx)
+		if err != nil {
+			return err
+		}
+
+		hc.mu.Lock()
+		if hc.generation == generation {
+			hc.reconcile(stored)
+			hc.mu.Unlock()
+			return nil
+		}
+		hc.mu.Unlock()
+		// The list may be missing targets written meanwhile, which reconciling would undo
+		if attempt == syncAttempts {
+			slog.Debug("targets changed while syncing, retrying with the next sync")
+			return nil
+		}
+	}
+}
+
+// reconcile replaces the targets registered through the API with stored, it must be
+// called with hc.mu held
+func (hc *HealthChecker) reconcile(stored []HealthTarget) {
+	listed := make(map[string]bool, len(stored))
+	invalid := make(map[string]bool)
+	for _, target := range stored {
+		// Written by an older version or edited by hand, their keys would be ambiguous
+		if err := validateTargetID(target.ID); err != nil {
+			if !hc.invalid[target.Key()] {
+				slog.Warn("ignoring stored target", "namespace", target.Namespace, "error", err)
+			}
+			invalid[target.Key()] = true
+			continue
+		}
+		listed[target.Key()] = true
+		target.Source = TargetSourceAPI
+
+		existing, ok := hc.targets[target.Key()]
+		if ok && existing.Source == TargetSourceConfig {
+			continue // shadowed by the config
+		}
+		if ok && sameTarget(existing, target) {
+			continue // unchanged, don't cancel checks in flight
+		}
+		hc.setTarget(target)
+	}
+
+	for key, target := range hc.targets {
+		if target.Source == TargetSourceAPI && !listed[key] {
+			hc.deleteTarget(key)
+		}
+	}
+	hc.invalid = invalid
+	hc.countTargets()
+}
+
+// SetConfigTargets replaces the targets declared in the config. Config targets take
+// precedence over API registered targets with the same ID, which stay in the store
+// and come back with the next Sync once the config no longer declares the ID.
+func (hc *HealthChecker) SetConfigTargets(targets []HealthTarget) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	declared := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		declared[target.Key()] = true
+		target.Source = TargetSourceConfig
+
+		existing, ok := hc.targets[target.Key()]
+		if ok && existing.Source != TargetSourceConfig {
+			slog.Warn("config target replaces target registered through the API", "id", target.ID, "namespace", target.Namespace)
+		}
+		if ok && existing.Source == target.Source && sameTarget(existing, target) {
+			continue // unchanged, don't cancel checks in flight
+		}
+		hc.setTarget(target)
+	}
+
+	for key, target := range hc.targets {
+		if target.Source == TargetSourceConfig && !declared[key] {
+			hc.deleteTarget(key)
+		}
+	}
+	hc.countTargets()
+}
+
+// sameTarget reports whether a and b check the same thing in the same way
+func sameTarget(a, b HealthTarget) bool {
+	return a.URLString == b.URLString && a.TimeoutInSec == b.TimeoutInSec && maps.Equal(a.Labels, b.Labels)
+}
+
+// setTarget stores the target, cancelling checks of a replaced target with the same key.
+// It must be called with hc.mu held.
+func (hc *HealthChecker) setTarget(target HealthTarget) {
+	key := target.Key()
+	if lifetime, ok := hc.lifetimes[key]; ok {
+		lifetime.cancel(ErrTargetRemoved)
+	}
+	ctx, cancel := context.WithCancelCause(context.Background())
+	hc.targets[key] = target
+	hc.lifetimes[key] = targetLifetime{ctx: ctx, cancel: cancel}
+}
+
+// deleteTarget removes the target and cancels its checks in flight.
+// It must be called with hc.mu held.
+func (hc *HealthChecker) deleteTarget(key string) {
+	if lifetime, ok := hc.lifetimes[key]; ok {
+		lifetime.cancel(ErrTargetRemoved)
+	}
+	delete(hc.targets, key)
+	delete(hc.lifetimes, key)
+}
+
+// checkHealth performs the health check for a single target. The check is cancelled
+// if ctx is done, the target's deadline is exceeded or lifetime is cancelled.
+func (hc *HealthChecker) checkHealth(ctx context.Context, target HealthTarget, lifetime context.Context) Result {
 	startTime := time.Now()
 	result := Result{
 		Target:    target,
 		Timestamp: startTime,
 	}
 
-	resp, err := hc.client.Get(target.URL.String())
+	timeout := time.Duration(hc.timeout.Load())
+	if target.TimeoutInSec > 0 {
+		timeout = time.Duration(target.TimeoutInSec) * time.Second
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stop := context.AfterFunc(lifetime, func() { cancel(context.Cause(lifetime)) })
+	defer stop()
+	ctx, cancelTimeout := context.WithTimeout(ctx, timeout)
+	defer cancelTimeout()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.URL.String(), nil)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	resp, err := hc.client.Do(req)
 	result.Duration = time.Since(startTime)
 
+	if err != nil && errors.Is(context.Cause(ctx), ErrTargetRemoved) {
+		// Nothing to report about a target that no longer exists
+		result.Error = ErrTargetRemoved
+		return result
+	}
+
 	// Record request duration
-	healthCheckDuration.WithLabelValues(target.ID, target.URLString).
+	healthCheckDuration.WithLabelValues(target.Namespace, target.ID, target.URLString).
 		Observe(result.Duration.Seconds())
 
 	if err != nil {
 		result.Error = err
+		errorType := "connection_error"
+		if errors.Is(err, context.DeadlineExceeded) {
+			errorType = "timeout"
+		} else if errors.Is(err, ErrEgressDenied) {
+			errorType = "egress_denied"
+		}
 		// Record error
 		healthCheckErrors.WithLabelValues(
+			target.Namespace,
 			target.ID,
 			target.URLString,
-			"connection_error",
+			errorType,
 		).Inc()
 		// Update status gauge to unhealthy
-		healthCheckStatus.WithLabelValues(target.ID, target.URLString).Set(0)
+		healthCheckStatus.WithLabelValues(target.Namespace, target.ID, target.URLString).Set(0)
 		return result
 	}
 	defer resp.Body.Close()
@@ -127,14 +567,15 @@ func (hc *HealthChecker) checkHealth(target HealthTarget) Result {
 	result.Healthy = resp.StatusCode >= 200 && resp.StatusCode < 300
 
 	// Update Prometheus metrics
-	healthCheckStatusCode.WithLabelValues(target.ID, target.URLString).
+	healthCheckStatusCode.WithLabelValues(target.Namespace, target.ID, target.URLString).
 		Set(float64(result.Status))
 
 	if result.Healthy {
-		healthCheckStatus.WithLabelValues(target.ID, target.URLString).Set(1)
+		healthCheckStatus.WithLabelValues(target.Namespace, target.ID, target.URLString).Set(1)
 	} else {
-		healthCheckStatus.WithLabelValues(target.ID, target.URLString).Set(0)
+		healthCheckStatus.WithLabelValues(target.Namespace, target.ID, target.URLString).Set(0)
 		healthCheckErrors.WithLabelValues(
+			target.Namespace,
 			target.ID,
 			target.URLString,
 			"unhealthy_status",
@@ -144,23 +585,38 @@ func (hc *HealthChecker) checkHealth(target HealthTarget) Result {
 	return result
 }
 
-// CheckTarget performs a health check on a single target
-func (hc *HealthChecker) CheckTarget(id string) (Result, error) {
+// CheckTarget performs a health check on the target with the given key
+func (hc *HealthChecker) CheckTarget(ctx context.Context, key string) (Result, error) {
 	hc.mu.RLock()
-	target, ok := hc.targets[id]
+	target, ok := hc.targets[key]
+	lifetime := hc.lifetimes[key]
 	hc.mu.RUnlock()
 
 	if !ok {
 		return Result{}, ErrTargetNotFound
 	}
 
-	return hc.checkHealth(target), nil
+	return hc.checkHealth(ctx, target, lifetime.ctx), nil
+}
+
+// CheckAll performs health checks on all targets concurrently.
+// Checks of targets removed while in flight are left out of the results.
+func (hc *HealthChecker) CheckAll(ctx context.Context) []Result {
+	return hc.checkTargets(ctx, func(HealthTarget) bool { return true })
+}
+
+// CheckNamespace performs health checks on all targets of a namespace concurrently
+func (hc *HealthChecker) CheckNamespace(ctx context.Context, namespace string) []Result {
+	return hc.checkTargets(ctx, func(target HealthTarget) bool { return target.Namespace == namespace })
 }
 
-// CheckAll performs health checks on all targets concurrently
-func (hc *HealthChecker) CheckAll() []Result {
+func (hc *HealthChecker) checkTargets(ctx context.Context, filter func(HealthTarget) bool) []Result {
 	hc.mu.RLock()
-	targets := MapValues(hc.targets)
+	targets := slices.DeleteFunc(MapValues(hc.targets), func(target HealthTarget) bool { return !filter(target) })
+	lifetimes := make([]context.Context, len(targets))
+	for i, target := range targets {
+		lifetimes[i] = hc.lifetimes[target.Key()].ctx
+	}
 	hc.mu.RUnlock()
 
 	results := make([]Result, len(targets))
@@ -169,58 +625,10 @@ func (hc *HealthChecker) CheckAll() []Result {
 		wg.Add(1)
 		go func(index int, t HealthTarget) {
 			defer wg.Done()
-			results[index] = hc.checkHealth(t)
+			results[index] = hc.checkHealth(ctx, t, lifetimes[index])
 		}(i, target)
 	}
 	wg.Wait()
-	return results
-}
 
-func (hc *HealthChecker) loadTargets() error {
-	data, err := os.ReadFile(hc.storePath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			// It's okay if the file doesn't exist yet
-			return nil
-		}
-		return errors.Wrap(err, "failed to read targets file")
-	}
-
-	var targetsData []HealthTarget
-	if err := json.Unmarshal(data, &targetsData); err != nil {
-		return errors.Wrap(err, "failed to unmarshal targets data")
-	}
-
-	// Clear existing targets and add loaded ones
-	hc.targets = make(map[string]HealthTarget)
-	for _, target := range targetsData {
-		// Parse URL strings back into URL objects
-		parsedURL, err := url.Parse(target.URLString)
-		if err != nil {
-			return errors.Wrap(err, "failed to parse URL from stored target")
-		}
-		target.URL = parsedURL
-		hc.targets[target.ID] = target
-		registeredTargets.Inc()
-	}
-
-	return nil
-}
-
-func (hc *HealthChecker) saveTargets() error {
-	targetsSlice := make([]HealthTarget, 0, len(hc.targets))
-	for _, target := range hc.targets {
-		targetsSlice = append(targetsSlice, target)
-	}
-
-	data, err := json.MarshalIndent(targetsSlice, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal targets data: %w", err)
-	}
-
-	if err := os.WriteFile(hc.storePath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write targets file: %w", err)
-	}
-
-	return nil
+	return slices.DeleteFunc(results, func(r Result) bool { return errors.Is(r.Error, ErrTargetRemoved) })
 }
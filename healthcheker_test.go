@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gitlab.com/tozd/go/errors"
+)
+
+func newHangingServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func mustTarget(t *testing.T, id, rawURL string) HealthTarget {
+	t.Helper()
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %v", err)
+	}
+	return HealthTarget{URL: parsedURL, URLString: rawURL, ID: id}
+}
+
+func TestCheckCancellation(t *testing.T) {
+	t.Run("Removing a target cancels its check in flight", func(t *testing.T) {
+		server := newHangingServer(t)
+		hc, _ := NewHealthChecker(time.Minute, nil)
+		hc.AddTarget(context.Background(), mustTarget(t, "hanging", server.URL))
+
+		done := make(chan error, 1)
+		go func() {
+			result, err := hc.CheckTarget(context.Background(), "hanging")
+			if err == nil {
+				err = result.Error
+			}
+			done <- err
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		hc.RemoveTarget(context.Background(), "hanging")
+
+		select {
+		case err := <-done:
+			if !errors.Is(err, ErrTargetRemoved) {
+				t.Fatalf("Expected ErrTargetRemoved, got %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Check was not cancelled")
+		}
+	})
+
+	t.Run("Removed targets are left out of CheckAll", func(t *testing.T) {
+		server := newHangingServer(t)
+		hc, _ := NewHealthChecker(time.Minute, nil)
+		hc.AddTarget(context.Background(), mustTarget(t, "hanging", server.URL))
+
+		time.AfterFunc(50*time.Millisecond, func() { hc.RemoveTarget(context.Background(), "hanging") })
+		if results := hc.CheckAll(context.Background()); len(results) != 0 {
+			t.Fatalf("Expected no results, got %v", results)
+		}
+	})
+
+	t.Run("Per-target timeout overrides the default", func(t *testing.T) {
+		server := newHangingServer(t)
+		hc, _ := NewHealthChecker(time.Minute, nil)
+		target := mustTarget(t, "hanging", server.URL)
+		target.TimeoutInSec = 1
+		hc.AddTarget(context.Background(), target)
+
+		result, err := hc.CheckTarget(context.Background(), "hanging")
+		if err != nil {
+			t.Fatalf("Failed to check target: %v", err)
+		}
+		if result.Healthy || !errors.Is(result.Error, context.DeadlineExceeded) {
+			t.Fatalf("Expected the check to time out, got %v", result.Error)
+		}
+	})
+
+	t.Run("Stopping the monitor cancels checks in flight after the grace period", func(t *testing.T) {
+		server := newHangingServer(t)
+		hc, _ := NewHealthChecker(time.Minute, nil)
+		hc.AddTarget(context.Background(), mustTarget(t, "hanging", server.URL))
+		monitor := NewHealthMonitor(hc, time.Hour, nil)
+		monitor.Start(context.Background())
+		time.Sleep(50 * time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		if err := monitor.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) || time.Since(start) > time.Second {
+			t.Fatalf("Expected the monitor to stop after the grace period, got %v after %s", err, time.Since(start))
+		}
+		if _, ok := monitor.GetState("hanging"); ok {
+			t.Fatalf("Expected the cancelled check not to be recorded")
+		}
+	})
+
+	t.Run("Cancelling the context cancels the check", func(t *testing.T) {
+		server := newHangingServer(t)
+		hc, _ := NewHealthChecker(time.Minute, nil)
+		hc.AddTarget(context.Background(), mustTarget(t, "hanging", server.URL))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		results := hc.CheckAll(ctx)
+		if len(results) != 1 || results[0].Healthy || results[0].Error == nil {
+			t.Fatalf("Expected one failed result, got %v", results)
+		}
+	})
+}
+
+func TestConfigTargets(t *testing.T) {
+	store, err := NewFileTargetStore(filepath.Join(t.TempDir(), "targets.json"), false)
+	if err != nil {
+		t.Fatalf("Failed to open target store: %v", err)
+	}
+	defer store.Close()
+	hc, err := NewHealthChecker(time.Second, store)
+	if err != nil {
+		t.Fatalf("Failed to create health checker: %v", err)
+	}
+	if err := hc.AddTarget(context.Background(), mustTarget(t, "shared", "https://api.example.com")); err != nil {
+		t.Fatalf("Failed to add target: %v", err)
+	}
+	if err := hc.AddTarget(context.Background(), mustTarget(t, "api-only", "https://api-only.example.com")); err != nil {
+		t.Fatalf("Failed to add target: %v", err)
+	}
+
+	configTarget := mustTarget(t, "shared", "https://config.example.com")
+	configTarget.Source = TargetSourceConfig
+	hc.SetConfigTargets([]HealthTarget{configTarget})
+
+	if target := hc.targets["shared"]; target.URLString != "https://config.example.com" || target.Source != TargetSourceConfig {
+		t.Fatalf("Expected the config target to win, got %+v", target)
+	}
+	if err := hc.RemoveTarget(context.Background(), "shared"); err == nil || err.Status != http.StatusForbidden {
+		t.Fatalf("Expected removing a config target to be forbidden, got %v", err)
+	}
+	if err := hc.AddTarget(context.Background(), mustTarget(t, "shared", "https://api.example.com")); err == nil || err.Status != http.StatusForbidden {
+		t.Fatalf("Expected replacing a config target to be forbidden, got %v", err)
+	}
+
+	// Config targets are not persisted to the target store
+	if stored, _ := store.List(context.Background()); len(stored) != 2 {
+		t.Fatalf("Expected only the API targets to be persisted, got %v", stored)
+	}
+
+	// The shadowed API target comes back once the config no longer declares it
+	hc.SetConfigTargets(nil)
+	if _, ok := hc.targets["shared"]; ok || len(hc.targets) != 1 {
+		t.Fatalf("Expected the config target to be removed, got %v", hc.targets)
+	}
+	if err := hc.Sync(context.Background()); err != nil {
+		t.Fatalf("Failed to sync targets: %v", err)
+	}
+	if target := hc.targets["shared"]; target.URLString != "https://api.example.com" || target.Source != TargetSourceAPI {
+		t.Fatalf("Expected the API target to be restored, got %+v", target)
+	}
+}
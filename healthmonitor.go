@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
 	"time"
+
+	"gitlab.com/tozd/go/errors"
 )
 
 // AlertFunc is called when a target's health state changes
@@ -11,89 +16,213 @@ type AlertFunc func(target HealthTarget, result Result) error
 
 // HealthMonitor manages periodic health checks and alerts
 type HealthMonitor struct {
-	checker      *HealthChecker
-	interval     time.Duration
-	alertFuncs   []AlertFunc
-	resolveFuncs []AlertFunc
-	stopChan     chan struct{}
-	stateMap     map[string]monitorState
-	stateMu      sync.RWMutex
+	checker       *HealthChecker
+	interval      time.Duration
+	intervalMu    sync.Mutex
+	intervalChan  chan struct{}
+	notifications *NotificationQueue
+	stopChan      chan struct{}
+	stopOnce      sync.Once
+	done          chan struct{}
+	checkCtx      context.Context // cancelled only if checks can't be drained in time
+	cancelChecks  context.CancelFunc
+	stateMap      map[string]monitorState
+	stateMu       sync.RWMutex
+	incidents     []TargetIncident // oldest first, guarded by stateMu
+	incidentID    int64
+	events        *EventBroker
+	silences      *Silences
 }
 
+// History and incidents are only kept in memory, so their size is bounded
+const (
+	historySize  = 60  // results per target
+	maxIncidents = 200 // across all targets
+)
+
 type monitorState struct {
 	consecutiveFailures int
 	alerted             bool
+	incident            int64 // ID of the open incident
 	lastResult          Result
+	lastChange          time.Time // when the target last turned healthy or unhealthy
+	history             []Result  // oldest first
+}
+
+// TargetIncident is opened when a target fails twice in a row and resolved once it recovers
+type TargetIncident struct {
+	ID         int64
+	Target     HealthTarget
+	OpenedAt   time.Time
+	ResolvedAt time.Time // zero while the incident is open
+	Error      string    // why the target was unhealthy when the incident was opened
+	Silenced   bool      // no alert was sent because of a silence
+	Removed    bool      // resolved because the target was removed, not because it recovered
+}
+
+// TargetStatus is the latest known health of a target
+type TargetStatus struct {
+	Target              HealthTarget
+	Pending             bool // not checked yet, so Result is empty
+	Result              Result
+	ConsecutiveFailures int
+	Alerted             bool
+	LastChange          time.Time
 }
 
 // NewHealthMonitor creates a new HealthMonitor instance
 func NewHealthMonitor(
 	checker *HealthChecker,
 	interval time.Duration,
-	alertFuncs []AlertFunc,
-	resolveFuncs []AlertFunc,
+	notifications *NotificationQueue,
 ) *HealthMonitor {
+	checkCtx, cancelChecks := context.WithCancel(context.Background())
 	return &HealthMonitor{
-		checker:      checker,
-		interval:     interval,
-		alertFuncs:   alertFuncs,
-		resolveFuncs: resolveFuncs,
-		stopChan:     make(chan struct{}),
-		stateMap:     make(map[string]monitorState),
+		checker:       checker,
+		interval:      interval,
+		intervalChan:  make(chan struct{}, 1),
+		notifications: notifications,
+		stopChan:      make(chan struct{}),
+		done:          make(chan struct{}),
+		checkCtx:      checkCtx,
+		cancelChecks:  cancelChecks,
+		stateMap:      make(map[string]monitorState),
+		events:        NewEventBroker(),
+		silences:      &Silences{},
 	}
 }
 
-// Start begins the monitoring process
-func (hm *HealthMonitor) Start() {
-	ticker := time.NewTicker(hm.interval)
-	hm.checkAll()
+// Start begins the monitoring process. No new checks are started once ctx is done or
+// Stop is called.
+func (hm *HealthMonitor) Start(ctx context.Context) {
 	go func() {
+		defer close(hm.done)
+
+		ticker := time.NewTicker(hm.getInterval())
+		defer ticker.Stop()
+
+		hm.checkAll()
 		for {
 			select {
 			case <-ticker.C:
 				hm.checkAll()
+			case <-hm.intervalChan:
+				ticker.Reset(hm.getInterval())
+			case <-ctx.Done():
+				return
 			case <-hm.stopChan:
-				ticker.Stop()
 				return
 			}
 		}
 	}()
 }
 
-// Stop ends the monitoring process
-func (hm *HealthMonitor) Stop() {
-	close(hm.stopChan)
+// SetInterval changes the time between check rounds
+func (hm *HealthMonitor) SetInterval(interval time.Duration) {
+	hm.intervalMu.Lock()
+	hm.interval = interval
+	hm.intervalMu.Unlock()
+
+	select {
+	case hm.intervalChan <- struct{}{}:
+	default:
+	}
+}
+
+func (hm *HealthMonitor) getInterval() time.Duration {
+	hm.intervalMu.Lock()
+	defer hm.intervalMu.Unlock()
+	return hm.interval
+}
+
+// Stop ends the monitoring process and waits for in-progress checks to finish.
+// If ctx expires first, the in-progress checks are cancelled.
+func (hm *HealthMonitor) Stop(ctx context.Context) error {
+	hm.stopOnce.Do(func() { close(hm.stopChan) })
+
+	select {
+	case <-hm.done:
+		return nil
+	case <-ctx.Done():
+		hm.cancelChecks()
+		<-hm.done
+		return ctx.Err()
+	}
 }
 
 func (hm *HealthMonitor) checkAll() {
-	results := hm.checker.CheckAll()
+	// Pick up targets registered through other replicas sharing the target store
+	if err := hm.checker.Sync(hm.checkCtx); err != nil {
+		slog.Error("failed to sync targets from the target store", "error", err)
+	}
+
+	results := hm.checker.CheckAll(hm.checkCtx)
 
 	for _, result := range results {
+		if errors.Is(result.Error, context.Canceled) {
+			// Cancelled on shutdown, this says nothing about the target's health
+			continue
+		}
 		hm.processResult(result)
 	}
+	hm.pruneStates()
+}
+
+// pruneStates forgets the state of removed targets. Their open incidents are closed as
+// removed, no resolution is sent since the target never recovered.
+func (hm *HealthMonitor) pruneStates() {
+	current := make(map[string]bool)
+	for _, target := range hm.checker.Targets() {
+		current[target.Key()] = true
+	}
+
+	hm.stateMu.Lock()
+	defer hm.stateMu.Unlock()
+	for id, state := range hm.stateMap {
+		if !current[id] {
+			if state.alerted {
+				if incident := hm.resolveIncident(state.incident, time.Now()); incident != nil {
+					incident.Removed = true
+				}
+				hm.events.Publish(Event{Type: EventIncident, Status: state.status(state.lastResult.Target), Incident: IncidentRemoved})
+			}
+			delete(hm.stateMap, id)
+		}
+	}
 }
 
 func (hm *HealthMonitor) processResult(result Result) {
+	// Enqueueing persists the queue, which mustn't hold up readers of the state
+	if kind := hm.updateState(result); kind != "" {
+		hm.notifications.Enqueue(kind, result)
+	}
+}
+
+// updateState records a scheduled check's result and returns the notification to
+// send for it, if any
+func (hm *HealthMonitor) updateState(result Result) NotificationKind {
 	hm.stateMu.Lock()
 	defer hm.stateMu.Unlock()
 
-	state, exists := hm.stateMap[result.Target.ID]
+	state, exists := hm.stateMap[result.Target.Key()]
 	if !exists {
 		state = monitorState{}
 	}
 
 	// Update state based on current health check
+	var notification NotificationKind
+	incident := ""
 	if !result.Healthy {
 		state.consecutiveFailures++
 	} else {
 		if state.alerted {
-			// If we previously alerted, call resolve functions
-			for _, resolveFunc := range hm.resolveFuncs {
-				if err := resolveFunc(result.Target, result); err != nil {
-					slog.Error("resolveFunc failed", "target", result.Target, "error", err)
-				}
+			// If we previously alerted, send resolution notices
+			if resolved := hm.resolveIncident(state.incident, result.Timestamp); resolved == nil || !resolved.Silenced {
+				notification = NotificationResolve
 			}
 			state.alerted = false
+			state.incident = 0
+			incident = IncidentResolved
 		}
 		state.consecutiveFailures = 0
 	}
@@ -101,23 +230,176 @@ func (hm *HealthMonitor) processResult(result Result) {
 	// Check if we need to alert
 	if state.consecutiveFailures >= 2 && !state.alerted {
 		// Alert on second consecutive failure
-		for _, alertFunc := range hm.alertFuncs {
-			if err := alertFunc(result.Target, result); err != nil {
-				slog.Error("alertFunc failed", "target", result.Target, "error", err)
-			}
+		silenced := hm.silences.Silenced(result.Target)
+		if !silenced {
+			notification = NotificationAlert
 		}
 		state.alerted = true
+		state.incident = hm.openIncident(result, silenced)
+		incident = IncidentOpened
 	}
 
-	state.lastResult = result
-	hm.stateMap[result.Target.ID] = state
+	changed := state.setLastResult(result, exists)
+	hm.stateMap[result.Target.Key()] = state
+	hm.publish(state.status(result.Target), changed, incident)
+	return notification
+}
+
+// RecordResult stores the result of an on-demand check as the latest result of its
+// target. Unlike the scheduled checks, it doesn't count towards alerting, so polling
+// a broken target doesn't page anyone sooner.
+func (hm *HealthMonitor) RecordResult(result Result) {
+	if errors.Is(result.Error, context.Canceled) || errors.Is(result.Error, ErrTargetRemoved) {
+		return
+	}
+
+	hm.stateMu.Lock()
+	defer hm.stateMu.Unlock()
+	state, exists := hm.stateMap[result.Target.Key()]
+	changed := state.setLastResult(result, exists)
+	hm.stateMap[result.Target.Key()] = state
+	hm.publish(state.status(result.Target), changed, "")
+}
+
+// setLastResult stores result and reports whether the target's health changed
+func (s *monitorState) setLastResult(result Result, exists bool) bool {
+	changed := !exists || s.lastResult.Healthy != result.Healthy
+	if changed {
+		s.lastChange = result.Timestamp
+	}
+	s.lastResult = result
+	s.history = append(s.history, result)
+	if len(s.history) > historySize {
+		s.history = s.history[1:]
+	}
+	return changed
+}
+
+// openIncident must be called with stateMu held
+func (hm *HealthMonitor) openIncident(result Result, silenced bool) int64 {
+	hm.incidentID++
+	incident := TargetIncident{ID: hm.incidentID, Target: result.Target, OpenedAt: result.Timestamp, Silenced: silenced}
+	if result.Error != nil {
+		incident.Error = result.Error.Error()
+	} else {
+		incident.Error = fmt.Sprintf("status %d", result.Status)
+	}
+	hm.incidents = append(hm.incidents, incident)
+	if len(hm.incidents) > maxIncidents {
+		hm.incidents = hm.incidents[1:]
+	}
+	return incident.ID
+}
+
+// resolveIncident must be called with stateMu held. It returns nil if the incident was
+// already dropped from the history.
+func (hm *HealthMonitor) resolveIncident(id int64, at time.Time) *TargetIncident {
+	for i := len(hm.incidents) - 1; i >= 0; i-- {
+		if hm.incidents[i].ID == id {
+			hm.incidents[i].ResolvedAt = at
+			return &hm.incidents[i]
+		}
+	}
+	return nil
+}
+
+// Incidents returns the incidents of a namespace, newest first
+func (hm *HealthMonitor) Incidents(namespace string) []TargetIncident {
+	hm.stateMu.RLock()
+	defer hm.stateMu.RUnlock()
+	var incidents []TargetIncident
+	for i := len(hm.incidents) - 1; i >= 0; i-- {
+		if hm.incidents[i].Target.Namespace == namespace {
+			incidents = append(incidents, hm.incidents[i])
+		}
+	}
+	return incidents
+}
+
+// History returns the latest results of the target with the given key, oldest first
+func (hm *HealthMonitor) History(key string) []Result {
+	hm.stateMu.RLock()
+	defer hm.stateMu.RUnlock()
+	return slices.Clone(hm.stateMap[key].history)
+}
+
+// Silences returns the rules suppressing alerts
+func (hm *HealthMonitor) Silences() *Silences {
+	return hm.silences
+}
+
+func (hm *HealthMonitor) publish(status TargetStatus, changed bool, incident string) {
+	hm.events.Publish(Event{Type: EventCheck, Status: status})
+	if changed {
+		hm.events.Publish(Event{Type: EventState, Status: status})
+	}
+	if incident != "" {
+		hm.events.Publish(Event{Type: EventIncident, Status: status, Incident: incident})
+	}
+}
+
+// Subscribe returns a subscription to the check results, health changes and incidents
+// filter returns true for. It must be closed once the subscriber is done.
+func (hm *HealthMonitor) Subscribe(filter func(Event) bool) *Subscription {
+	return hm.events.Subscribe(filter)
+}
+
+// CloseSubscriptions ends all subscriptions, so streaming clients disconnect on shutdown
+func (hm *HealthMonitor) CloseSubscriptions() {
+	hm.events.Close()
+}
+
+// Statuses returns the latest results of the targets of a namespace ordered by ID.
+// Targets that weren't checked yet are pending.
+func (hm *HealthMonitor) Statuses(namespace string) []TargetStatus {
+	targets := hm.checker.NamespaceTargets(namespace)
+
+	hm.stateMu.RLock()
+	defer hm.stateMu.RUnlock()
+
+	statuses := make([]TargetStatus, 0, len(targets))
+	for _, target := range targets {
+		state, ok := hm.stateMap[target.Key()]
+		if !ok {
+			statuses = append(statuses, TargetStatus{Target: target, Pending: true, Result: Result{Target: target}})
+			continue
+		}
+		statuses = append(statuses, state.status(target))
+	}
+	return statuses
+}
+
+// Status returns the latest result of the target with the given key
+func (hm *HealthMonitor) Status(key string) (TargetStatus, bool) {
+	target, ok := hm.checker.Target(key)
+	if !ok {
+		return TargetStatus{}, false
+	}
+
+	hm.stateMu.RLock()
+	defer hm.stateMu.RUnlock()
+	state, ok := hm.stateMap[key]
+	if !ok {
+		return TargetStatus{}, false
+	}
+	return state.status(target), true
+}
+
+func (s monitorState) status(target HealthTarget) TargetStatus {
+	return TargetStatus{
+		Target:              target,
+		Result:              s.lastResult,
+		ConsecutiveFailures: s.consecutiveFailures,
+		Alerted:             s.alerted,
+		LastChange:          s.lastChange,
+	}
 }
 
-// GetState returns the current state for a target
-func (hm *HealthMonitor) GetState(targetID string) (monitorState, bool) {
+// GetState returns the current state for the target with the given key
+func (hm *HealthMonitor) GetState(key string) (monitorState, bool) {
 	hm.stateMu.RLock()
 	defer hm.stateMu.RUnlock()
 
-	state, exists := hm.stateMap[targetID]
+	state, exists := hm.stateMap[key]
 	return state, exists
 }
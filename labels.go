@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gitlab.com/tozd/go/errors"
+)
+
+var (
+	labelKeyRegexp   = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.\-/]*$`)
+	labelValueRegexp = regexp.MustCompile(`^[A-Za-z0-9_.\-/]*$`)
+)
+
+// validateLabels checks that label keys and values only use characters which can't be
+// confused with the syntax of label selectors or the CSV label column
+func validateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if !labelKeyRegexp.MatchString(key) {
+			return fmt.Errorf("invalid label key %q", key)
+		}
+		if !labelValueRegexp.MatchString(value) {
+			return fmt.Errorf("invalid value %q of label %q", value, key)
+		}
+	}
+	return nil
+}
+
+// formatLabels formats labels as comma separated key=value pairs, ordered by key
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// parseLabels parses the output of formatLabels
+func parseLabels(s string) (map[string]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, errors.Errorf("label %q is missing a value", pair)
+		}
+		labels[key] = value
+	}
+	return labels, validateLabels(labels)
+}
+
+type labelOp int
+
+const (
+	labelEquals labelOp = iota
+	labelNotEquals
+	labelExists
+	labelNotExists
+)
+
+// labelRequirement is a single term of a label selector
+type labelRequirement struct {
+	key   string
+	op    labelOp
+	value string
+}
+
+// labelSelector selects targets by their labels. All requirements must match.
+type labelSelector []labelRequirement
+
+// parseLabelSelector parses selectors of the form key=value, key!=value, key and !key.
+// Each of the selectors may contain several comma separated ones.
+func parseLabelSelector(selectors []string) (labelSelector, error) {
+	var selector labelSelector
+	for _, s := range selectors {
+		for _, term := range strings.Split(s, ",") {
+			term = strings.TrimSpace(term)
+			if term == "" {
+				continue
+			}
+
+			var req labelRequirement
+			if key, value, ok := strings.Cut(term, "!="); ok {
+				req = labelRequirement{key: key, op: labelNotEquals, value: value}
+			} else if key, value, ok := strings.Cut(term, "="); ok {
+				req = labelRequirement{key: key, op: labelEquals, value: value}
+			} else if key, ok := strings.CutPrefix(term, "!"); ok {
+				req = labelRequirement{key: key, op: labelNotExists}
+			} else {
+				req = labelRequirement{key: term, op: labelExists}
+			}
+
+			if !labelKeyRegexp.MatchString(req.key) || !labelValueRegexp.MatchString(req.value) {
+				return nil, errors.Errorf("invalid label selector %q", term)
+			}
+			selector = append(selector, req)
+		}
+	}
+	return selector, nil
+}
+
+// Matches returns true if labels fulfil all requirements of the selector
+func (s labelSelector) Matches(labels map[string]string) bool {
+	for _, req := range s {
+		value, ok := labels[req.key]
+		switch req.op {
+		case labelEquals:
+			if !ok || value != req.value {
+				return false
+			}
+		case labelNotEquals:
+			if ok && value == req.value {
+				return false
+			}
+		case labelExists:
+			if !ok {
+				return false
+			}
+		case labelNotExists:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
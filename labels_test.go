@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestLabelSelector(t *testing.T) {
+	labels := map[string]string{"env": "prod", "team": "payments"}
+
+	tests := []struct {
+		selector []string
+		matches  bool
+	}{
+		{nil, true},
+		{[]string{"env=prod"}, true},
+		{[]string{"env=staging"}, false},
+		{[]string{"env!=staging"}, true},
+		{[]string{"tier!=critical"}, true},
+		{[]string{"env=prod,team=payments"}, true},
+		{[]string{"env=prod", "team=search"}, false},
+		{[]string{"team"}, true},
+		{[]string{"!team"}, false},
+		{[]string{"!tier"}, true},
+	}
+	for _, test := range tests {
+		selector, err := parseLabelSelector(test.selector)
+		if err != nil {
+			t.Fatalf("%v: failed to parse: %v", test.selector, err)
+		}
+		if selector.Matches(labels) != test.matches {
+			t.Errorf("%v: expected %t", test.selector, test.matches)
+		}
+	}
+
+	for _, invalid := range []string{"=prod", "env=a b", "!"} {
+		if _, err := parseLabelSelector([]string{invalid}); err == nil {
+			t.Errorf("%q: expected an error", invalid)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"gitlab.com/tozd/go/errors"
+)
+
+// maxListLimit is the largest page size
+const maxListLimit = 1000
+
+// listCursor points at the last item of a page. It stores the sort key instead of an
+// offset, so targets added or removed between requests don't shift the later pages.
+type listCursor struct {
+	Sort string `json:"s"`
+	Key  int64  `json:"k,omitempty"`
+	ID   string `json:"i"`
+}
+
+func (c listCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeListCursor(s string) (listCursor, error) {
+	var c listCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, errors.New("invalid cursor")
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, errors.New("invalid cursor")
+	}
+	return c, nil
+}
+
+// listPage describes which items of a listing to return
+type listPage struct {
+	sort   string // the sort key name, prefixed with - for descending order
+	limit  *int
+	cursor *string
+}
+
+// paginate orders items by key and then ID and returns the page described by p, with
+// the cursor of the next page or an empty string if this is the last page
+func paginate[T any](items []T, p listPage, key func(T) int64, id func(T) string) ([]T, string, error) {
+	if p.limit != nil && (*p.limit < 1 || *p.limit > maxListLimit) {
+		return nil, "", errors.Errorf("limit must be between 1 and %d", maxListLimit)
+	}
+
+	desc := strings.HasPrefix(p.sort, "-")
+	compare := func(aKey int64, aID string, bKey int64, bID string) int {
+		c := cmp.Or(cmp.Compare(aKey, bKey), strings.Compare(aID, bID))
+		if desc {
+			return -c
+		}
+		return c
+	}
+	slices.SortFunc(items, func(a, b T) int {
+		return compare(key(a), id(a), key(b), id(b))
+	})
+
+	if p.cursor != nil {
+		cursor, err := decodeListCursor(*p.cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		if cursor.Sort != p.sort {
+			return nil, "", errors.New("cursor belongs to a different sort order")
+		}
+		start, _ := slices.BinarySearchFunc(items, cursor, func(item T, c listCursor) int {
+			if compare(key(item), id(item), c.Key, c.ID) <= 0 {
+				return -1
+			}
+			return 1
+		})
+		items = items[start:]
+	}
+
+	if p.limit == nil || len(items) <= *p.limit {
+		return items, "", nil
+	}
+	items = items[:*p.limit]
+	last := items[len(items)-1]
+	return items, listCursor{Sort: p.sort, Key: key(last), ID: id(last)}.encode(), nil
+}
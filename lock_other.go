@@ -0,0 +1,10 @@
+//go:build !unix && !windows
+
+package main
+
+import "os"
+
+// lockFile is a no-op on platforms without file locking
+func lockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,20 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"syscall"
+
+	"gitlab.com/tozd/go/errors"
+)
+
+// lockFile takes an exclusive lock on f without blocking. The lock is released when
+// f is closed or the process exits.
+func lockFile(f *os.File) error {
+	err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return ErrTargetFileLocked
+	}
+	return err
+}
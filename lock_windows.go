@@ -0,0 +1,24 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+
+	"gitlab.com/tozd/go/errors"
+	"golang.org/x/sys/windows"
+)
+
+// lockFile takes an exclusive lock on f without blocking. The lock is released when
+// f is closed or the process exits.
+func lockFile(f *os.File) error {
+	err := windows.LockFileEx(
+		windows.Handle(f.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY,
+		0, 1, 0, &windows.Overlapped{},
+	)
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return ErrTargetFileLocked
+	}
+	return err
+}
@@ -1,13 +1,17 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
 	"net/smtp"
 	"strings"
+	"sync"
 	"time"
 
 	"gitlab.com/tozd/go/errors"
+	"golang.org/x/oauth2"
 )
 
 // loginAuth is a custom authentication mechanism that implements LOGIN auth
@@ -46,95 +50,224 @@ func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
 	}
 }
 
-// Rest of EmailConfig remains the same
+// xoauth2Auth implements the XOAUTH2 mechanism used by Google Workspace and Microsoft 365
+type xoauth2Auth struct {
+	username    string
+	accessToken string
+}
+
+func XOAuth2Auth(username, accessToken string) smtp.Auth {
+	return &xoauth2Auth{username, accessToken}
+}
+
+func (a *xoauth2Auth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	resp := fmt.Sprintf("user=%s\x01auth=Bearer %s\x01\x01", a.username, a.accessToken)
+	return "XOAUTH2", []byte(resp), nil
+}
+
+func (a *xoauth2Auth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if more {
+		// The server sends a JSON error challenge which has to be answered with an
+		// empty response, after which it reports the actual failure.
+		return []byte{}, nil
+	}
+	return nil, nil
+}
+
+func isLocalhost(name string) bool {
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
+
+const (
+	AuthTypeNone    = "none"
+	AuthTypeLogin   = "login"
+	AuthTypePlain   = "plain"
+	AuthTypeCramMD5 = "cram-md5"
+	AuthTypeXOAuth2 = "xoauth2"
+)
+
 type EmailConfig struct {
-	From         string   `json:"from"`
-	Password     string   `json:"password"`
-	SMTPHost     string   `json:"smtpHost"`
-	SMTPPort     string   `json:"smtpPort"`
-	ToEmails     []string `json:"toEmails"`
-	AuthType     string   `json:"authType,omitempty"`
-	UseTLS       bool     `json:"useTLS,omitempty"`
-	StartTLSAuth bool     `json:"startTLSAuth,omitempty"`
-}
-
-func (c EmailConfig) getAuth() smtp.Auth {
-	switch c.AuthType {
-	case "login":
-		return LoginAuth(c.From, c.Password)
+	From         string        `json:"from"`
+	Username     string        `json:"username,omitempty"` // Defaults to From
+	Password     string        `json:"password,omitempty"`
+	SMTPHost     string        `json:"smtpHost"`
+	SMTPPort     string        `json:"smtpPort"`
+	ToEmails     []string      `json:"toEmails"`
+	AuthType     string        `json:"authType,omitempty"` // none, login (default), plain, cram-md5 or xoauth2
+	OAuth2       *OAuth2Config `json:"oauth2,omitempty"`   // Required for xoauth2
+	UseTLS       bool          `json:"useTLS,omitempty"`
+	StartTLSAuth bool          `json:"startTLSAuth,omitempty"`
+	TimeoutInSec int           `json:"timeoutInSec,omitempty"` // Limits connecting and sending a mail, defaults to defaultSMTPTimeout
+}
+
+// defaultSMTPTimeout keeps an unresponsive SMTP server from blocking its channel, so
+// the notification is retried instead
+const defaultSMTPTimeout = 30 * time.Second
+
+func (c EmailConfig) timeout() time.Duration {
+	if c.TimeoutInSec > 0 {
+		return time.Duration(c.TimeoutInSec) * time.Second
+	}
+	return defaultSMTPTimeout
+}
+
+// OAuth2Config holds the credentials used to obtain access tokens for XOAUTH2.
+// Either a static AccessToken or a RefreshToken together with the client credentials
+// and TokenURL has to be provided. Refreshed tokens are cached until they expire.
+type OAuth2Config struct {
+	ClientID     string   `json:"clientId,omitempty"`
+	ClientSecret string   `json:"clientSecret,omitempty"`
+	TokenURL     string   `json:"tokenUrl,omitempty"`
+	RefreshToken string   `json:"refreshToken,omitempty"`
+	AccessToken  string   `json:"accessToken,omitempty"`
+	Scopes       []string `json:"scopes,omitempty"`
+
+	once   sync.Once
+	source oauth2.TokenSource
+}
+
+func (c *OAuth2Config) token() (string, error) {
+	if c.RefreshToken == "" {
+		if c.AccessToken == "" {
+			return "", errors.New("oauth2 requires either an accessToken or a refreshToken")
+		}
+		return c.AccessToken, nil
+	}
+
+	c.once.Do(func() {
+		conf := &oauth2.Config{
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			Endpoint:     oauth2.Endpoint{TokenURL: c.TokenURL},
+			Scopes:       c.Scopes,
+		}
+		// The configured access token's expiry is unknown and a token without one never
+		// expires, so it is left out to refresh right away and whenever it runs out
+		c.source = conf.TokenSource(context.Background(), &oauth2.Token{RefreshToken: c.RefreshToken})
+	})
+
+	tok, err := c.source.Token()
+	if err != nil {
+		return "", errors.Errorf("failed to refresh oauth2 token: %w", err)
+	}
+	return tok.AccessToken, nil
+}
+
+func (c EmailConfig) username() string {
+	if c.Username != "" {
+		return c.Username
+	}
+	return c.From
+}
+
+// getAuth returns the configured auth mechanism, or nil if no authentication should be performed
+func (c EmailConfig) getAuth() (smtp.Auth, error) {
+	switch strings.ToLower(c.AuthType) {
+	case AuthTypeNone:
+		return nil, nil
+	case "", AuthTypeLogin:
+		// LOGIN is the default since that's what most servers support
+		return LoginAuth(c.username(), c.Password), nil
+	case AuthTypePlain:
+		return smtp.PlainAuth("", c.username(), c.Password, c.SMTPHost), nil
+	case AuthTypeCramMD5:
+		return smtp.CRAMMD5Auth(c.username(), c.Password), nil
+	case AuthTypeXOAuth2:
+		if c.OAuth2 == nil {
+			return nil, errors.New("authType xoauth2 requires an oauth2 config")
+		}
+		token, err := c.OAuth2.token()
+		if err != nil {
+			return nil, err
+		}
+		return XOAuth2Auth(c.username(), token), nil
 	default:
-		// Fallback to LOGIN auth since that's what the server supports
-		return LoginAuth(c.From, c.Password)
+		return nil, errors.Errorf("unsupported authType %q", c.AuthType)
 	}
 }
 
 func (c EmailConfig) sendMail(subject, body string) error {
-	message := []byte(fmt.Sprintf("To: %s\r\n"+
+	message := []byte(fmt.Sprintf("From: %s\r\n"+
+		"To: %s\r\n"+
 		"Subject: %s\r\n"+
 		"\r\n"+
 		"%s\r\n",
+		c.From,
 		strings.Join(c.ToEmails, ","),
 		subject,
 		body))
 
-	auth := c.getAuth()
-	addr := c.SMTPHost + ":" + c.SMTPPort
-
-	if c.UseTLS {
-		return c.sendMailTLS(auth, message)
-	} else if c.StartTLSAuth {
-		return c.sendMailStartTLS(auth, message)
-	}
-
-	return smtp.SendMail(addr, auth, c.From, c.ToEmails, message)
-}
-
-func (c EmailConfig) sendMailTLS(auth smtp.Auth, message []byte) error {
-	addr := c.SMTPHost + ":" + c.SMTPPort
-
-	tlsConfig := &tls.Config{
-		ServerName: c.SMTPHost,
-		MinVersion: tls.VersionTLS12,
-	}
-
-	conn, err := tls.Dial("tcp", addr, tlsConfig)
+	auth, err := c.getAuth()
 	if err != nil {
-		return errors.Errorf("failed to create TLS connection: %w", err)
+		return err
 	}
-	defer conn.Close()
 
-	client, err := smtp.NewClient(conn, c.SMTPHost)
+	client, err := c.dial()
 	if err != nil {
-		return errors.Errorf("failed to create SMTP client: %w", err)
+		return err
 	}
 	defer client.Close()
 
+	if c.StartTLSAuth {
+		if err := client.StartTLS(&tls.Config{
+			ServerName: c.SMTPHost,
+			MinVersion: tls.VersionTLS12,
+		}); err != nil {
+			return errors.Errorf("failed to start TLS: %w", err)
+		}
+	} else if ok, _ := client.Extension("STARTTLS"); ok && !c.UseTLS {
+		// Opportunistic like smtp.SendMail
+		if err := client.StartTLS(&tls.Config{ServerName: c.SMTPHost}); err != nil {
+			return errors.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
 	return c.sendMailWithClient(client, auth, message)
 }
 
-// Rest of the code remains the same...
-func (c EmailConfig) sendMailStartTLS(auth smtp.Auth, message []byte) error {
-	addr := c.SMTPHost + ":" + c.SMTPPort
+// dial connects to the SMTP server, with implicit TLS if configured. The whole
+// conversation has to finish within the timeout.
+func (c EmailConfig) dial() (*smtp.Client, error) {
+	addr := net.JoinHostPort(c.SMTPHost, c.SMTPPort)
+	dialer := &net.Dialer{Timeout: c.timeout()}
 
-	client, err := smtp.Dial(addr)
-	if err != nil {
-		return errors.Errorf("failed to dial SMTP server: %w", err)
+	var conn net.Conn
+	var err error
+	if c.UseTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{
+			ServerName: c.SMTPHost,
+			MinVersion: tls.VersionTLS12,
+		})
+		if err != nil {
+			return nil, errors.Errorf("failed to create TLS connection: %w", err)
+		}
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+		if err != nil {
+			return nil, errors.Errorf("failed to dial SMTP server: %w", err)
+		}
 	}
-	defer client.Close()
-
-	if err := client.StartTLS(&tls.Config{
-		ServerName: c.SMTPHost,
-		MinVersion: tls.VersionTLS12,
-	}); err != nil {
-		return errors.Errorf("failed to start TLS: %w", err)
+	if err := conn.SetDeadline(time.Now().Add(c.timeout())); err != nil {
+		conn.Close()
+		return nil, err
 	}
 
-	return c.sendMailWithClient(client, auth, message)
+	client, err := smtp.NewClient(conn, c.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return nil, errors.Errorf("failed to create SMTP client: %w", err)
+	}
+	return client, nil
 }
 
 func (c EmailConfig) sendMailWithClient(client *smtp.Client, auth smtp.Auth, message []byte) error {
-	if err := client.Auth(auth); err != nil {
-		return errors.Errorf("failed to authenticate: %w", err)
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return errors.Errorf("failed to authenticate: %w", err)
+		}
 	}
 
 	if err := client.Mail(c.From); err != nil {
@@ -151,14 +284,19 @@ func (c EmailConfig) sendMailWithClient(client *smtp.Client, auth smtp.Auth, mes
 	if err != nil {
 		return errors.Errorf("failed to open mail writer: %w", err)
 	}
-	defer w.Close()
 
 	_, err = w.Write(message)
 	if err != nil {
+		_ = w.Close()
 		return errors.Errorf("failed to write mail content: %w", err)
 	}
 
-	return nil
+	// The server only accepts the message once the writer is closed
+	if err := w.Close(); err != nil {
+		return errors.Errorf("failed to send mail content: %w", err)
+	}
+
+	return client.Quit()
 }
 
 func NewEmailAlert(config EmailConfig) AlertFunc {
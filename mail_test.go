@@ -1,7 +1,17 @@
 package main
 
 import (
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
 	"net/url"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -39,3 +49,331 @@ func TestMail(t *testing.T) {
 		}
 	})
 }
+
+// fakeSMTPServer is a minimal SMTP server accepting a single set of credentials
+type fakeSMTPServer struct {
+	listener   net.Listener
+	mechanisms []string
+	username   string
+	secret     string // password, or the expected bearer token for XOAUTH2
+
+	mu       sync.Mutex
+	authUsed []string
+	messages []string
+}
+
+func newFakeSMTPServer(t *testing.T, username, secret string, mechanisms ...string) *fakeSMTPServer {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	s := &fakeSMTPServer{listener: l, mechanisms: mechanisms, username: username, secret: secret}
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) config() EmailConfig {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return EmailConfig{
+		From:     "doctor@example.com",
+		SMTPHost: host,
+		SMTPPort: port,
+		ToEmails: []string{"admin@example.com"},
+	}
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	authenticated := len(s.mechanisms) == 0
+	_ = tp.PrintfLine("220 localhost ESMTP fake")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			if len(s.mechanisms) == 0 {
+				_ = tp.PrintfLine("250 localhost")
+			} else {
+				_ = tp.PrintfLine("250-localhost")
+				_ = tp.PrintfLine("250 AUTH %s", strings.Join(s.mechanisms, " "))
+			}
+		case "AUTH":
+			mechanism, initial, _ := strings.Cut(arg, " ")
+			if s.authenticate(tp, strings.ToUpper(mechanism), initial) {
+				authenticated = true
+				s.mu.Lock()
+				s.authUsed = append(s.authUsed, strings.ToUpper(mechanism))
+				s.mu.Unlock()
+				_ = tp.PrintfLine("235 Authentication successful")
+			} else {
+				_ = tp.PrintfLine("535 Authentication failed")
+			}
+		case "MAIL", "RCPT":
+			if !authenticated {
+				_ = tp.PrintfLine("530 Authentication required")
+				continue
+			}
+			_ = tp.PrintfLine("250 OK")
+		case "DATA":
+			_ = tp.PrintfLine("354 Go ahead")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, string(data))
+			s.mu.Unlock()
+			_ = tp.PrintfLine("250 OK")
+		case "QUIT":
+			_ = tp.PrintfLine("221 Bye")
+			return
+		default:
+			_ = tp.PrintfLine("250 OK")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) authenticate(tp *textproto.Conn, mechanism, initial string) bool {
+	challenge := func(prompt string) (string, bool) {
+		_ = tp.PrintfLine("334 %s", base64.StdEncoding.EncodeToString([]byte(prompt)))
+		line, err := tp.ReadLine()
+		if err != nil {
+			return "", false
+		}
+		decoded, err := base64.StdEncoding.DecodeString(line)
+		return string(decoded), err == nil
+	}
+	decodeInitial := func() (string, bool) {
+		if initial == "" {
+			return challenge("")
+		}
+		decoded, err := base64.StdEncoding.DecodeString(initial)
+		return string(decoded), err == nil
+	}
+
+	switch mechanism {
+	case "PLAIN":
+		resp, ok := decodeInitial()
+		parts := strings.Split(resp, "\x00")
+		return ok && len(parts) == 3 && parts[1] == s.username && parts[2] == s.secret
+	case "LOGIN":
+		user, ok := challenge("Username:")
+		if !ok {
+			return false
+		}
+		pass, ok := challenge("Password:")
+		return ok && user == s.username && pass == s.secret
+	case "CRAM-MD5":
+		nonce := "<1234.5678@localhost>"
+		resp, ok := challenge(nonce)
+		mac := hmac.New(md5.New, []byte(s.secret))
+		mac.Write([]byte(nonce))
+		return ok && resp == fmt.Sprintf("%s %x", s.username, mac.Sum(nil))
+	case "XOAUTH2":
+		resp, ok := decodeInitial()
+		return ok && resp == fmt.Sprintf("user=%s\x01auth=Bearer %s\x01\x01", s.username, s.secret)
+	default:
+		return false
+	}
+}
+
+func TestMailAuth(t *testing.T) {
+	target := HealthTarget{ID: "example", URLString: "https://example.com"}
+	result := Result{Target: target, Status: 503, Timestamp: time.Now()}
+
+	tests := []struct {
+		name      string
+		mechanism string
+		configure func(c *EmailConfig)
+		wantAuth  string
+	}{
+		{
+			name:      "login uses from as username by default",
+			mechanism: "LOGIN",
+			configure: func(c *EmailConfig) {
+				c.Username = ""
+				c.From = "relay-user"
+			},
+			wantAuth: "LOGIN",
+		},
+		{
+			name:      "plain",
+			mechanism: "PLAIN",
+			configure: func(c *EmailConfig) { c.AuthType = AuthTypePlain },
+			wantAuth:  "PLAIN",
+		},
+		{
+			name:      "cram-md5",
+			mechanism: "CRAM-MD5",
+			configure: func(c *EmailConfig) { c.AuthType = AuthTypeCramMD5 },
+			wantAuth:  "CRAM-MD5",
+		},
+		{
+			name:      "xoauth2 with static token",
+			mechanism: "XOAUTH2",
+			configure: func(c *EmailConfig) {
+				c.AuthType = AuthTypeXOAuth2
+				c.OAuth2 = &OAuth2Config{AccessToken: "secret"}
+			},
+			wantAuth: "XOAUTH2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeSMTPServer(t, "relay-user", "secret", tt.mechanism)
+			config := server.config()
+			config.Username = "relay-user"
+			config.Password = "secret"
+			tt.configure(&config)
+
+			if err := NewEmailAlert(config)(target, result); err != nil {
+				t.Fatalf("Failed to send mail: %v", err)
+			}
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			if len(server.authUsed) != 1 || server.authUsed[0] != tt.wantAuth {
+				t.Fatalf("Expected %s authentication, got %v", tt.wantAuth, server.authUsed)
+			}
+			if len(server.messages) != 1 || !strings.Contains(server.messages[0], "https://example.com is DOWN") {
+				t.Fatalf("Expected one alert mail, got %q", server.messages)
+			}
+		})
+	}
+
+	t.Run("wrong password is rejected", func(t *testing.T) {
+		server := newFakeSMTPServer(t, "relay-user", "secret", "PLAIN")
+		config := server.config()
+		config.Username = "relay-user"
+		config.Password = "wrong"
+		config.AuthType = AuthTypePlain
+
+		if err := NewEmailAlert(config)(target, result); err == nil {
+			t.Fatal("Expected authentication to fail")
+		}
+	})
+
+	t.Run("unauthenticated relay", func(t *testing.T) {
+		server := newFakeSMTPServer(t, "", "")
+		config := server.config()
+		config.AuthType = AuthTypeNone
+
+		if err := NewEmailAlert(config)(target, result); err != nil {
+			t.Fatalf("Failed to send mail: %v", err)
+		}
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		if len(server.authUsed) != 0 || len(server.messages) != 1 {
+			t.Fatalf("Expected one unauthenticated mail, got auth %v and %d messages", server.authUsed, len(server.messages))
+		}
+	})
+
+	t.Run("xoauth2 refreshes the access token", func(t *testing.T) {
+		var refreshes int
+		tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = r.ParseForm()
+			if r.Form.Get("grant_type") != "refresh_token" || r.Form.Get("refresh_token") != "refresh-me" {
+				http.Error(w, "invalid_grant", http.StatusBadRequest)
+				return
+			}
+			refreshes++
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"access_token":"fresh-token","token_type":"Bearer","expires_in":3600}`)
+		}))
+		defer tokenServer.Close()
+
+		server := newFakeSMTPServer(t, "relay-user", "fresh-token", "XOAUTH2")
+		config := server.config()
+		config.Username = "relay-user"
+		config.AuthType = AuthTypeXOAuth2
+		config.OAuth2 = &OAuth2Config{
+			ClientID:     "client",
+			ClientSecret: "client-secret",
+			TokenURL:     tokenServer.URL,
+			RefreshToken: "refresh-me",
+		}
+
+		sendErrorMail := NewEmailAlert(config)
+		for i := 0; i < 2; i++ {
+			if err := sendErrorMail(target, result); err != nil {
+				t.Fatalf("Failed to send mail: %v", err)
+			}
+		}
+		if refreshes != 1 {
+			t.Fatalf("Expected the token to be refreshed once and cached, got %d refreshes", refreshes)
+		}
+
+		// A configured access token alongside the refresh token must not stop refreshes
+		server = newFakeSMTPServer(t, "relay-user", "fresh-token", "XOAUTH2")
+		config = server.config()
+		config.Username = "relay-user"
+		config.AuthType = AuthTypeXOAuth2
+		config.OAuth2 = &OAuth2Config{
+			ClientID:     "client",
+			ClientSecret: "client-secret",
+			TokenURL:     tokenServer.URL,
+			RefreshToken: "refresh-me",
+			AccessToken:  "stale-token",
+		}
+		if err := NewEmailAlert(config)(target, result); err != nil {
+			t.Fatalf("Failed to send mail: %v", err)
+		}
+		if refreshes != 2 {
+			t.Fatalf("Expected the token endpoint to be called despite the access token, got %d refreshes", refreshes)
+		}
+	})
+
+	t.Run("unresponsive server times out", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Failed to listen: %v", err)
+		}
+		defer listener.Close()
+		go func() {
+			// Accept connections but never send the greeting
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+			}
+		}()
+
+		host, port, _ := net.SplitHostPort(listener.Addr().String())
+		config := EmailConfig{
+			From:         "monitor@example.com",
+			SMTPHost:     host,
+			SMTPPort:     port,
+			ToEmails:     []string{"ops@example.com"},
+			AuthType:     AuthTypeNone,
+			TimeoutInSec: 1,
+		}
+
+		start := time.Now()
+		if err := NewEmailAlert(config)(target, result); err == nil {
+			t.Fatal("Expected sending to an unresponsive server to fail")
+		}
+		if elapsed := time.Since(start); elapsed > 3*time.Second {
+			t.Fatalf("Expected the mail to time out after a second, took %s", elapsed)
+		}
+	})
+}
@@ -1,52 +1,294 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+const usage = `Usage: doctor [command] [flags]
+
+Commands:
+  serve            Run the health checker and API server (default)
+  notify-test      Send a test alert and resolution through every notification channel
+  validate-config  Check a config file against the config schema
+  targets          List, add or remove the targets of a running Doctor: targets list|add|rm
+  status           Print the health of all targets of a running Doctor, exits with 1 if one is unhealthy
+                   or not checked yet
+  check            Check a URL once without a running Doctor, exits with 1 if it's unhealthy
+  run -once        Check the targets declared in the config, not the ones registered through the
+                   API, and exit with 1 if one is unhealthy, e.g. in CI
+
+Run 'doctor <command> -h' for the flags of a command. The targets and status commands talk to
+the API at $DOCTOR_SERVER with the key in $DOCTOR_API_KEY, or the -server and -api-key flags.
+`
+
 func main() {
-	configFile := flag.String("config", "", "Path to config file")
-	flag.Parse()
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	switch command {
+	case "serve":
+		serve(args)
+	case "notify-test":
+		os.Exit(notifyTest(args, os.Stdout))
+	case "validate-config":
+		os.Exit(validateConfig(args))
+	case "targets":
+		os.Exit(targetsCommand(args, os.Stdout))
+	case "status":
+		os.Exit(statusCommand(args, os.Stdout))
+	case "check":
+		os.Exit(checkCommand(args, os.Stdout))
+	case "run":
+		os.Exit(runCommand(args, os.Stdout))
+	case "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+}
+
+func serve(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	configFile := flags.String("config", "", "Path to config file")
+	recoverTargets := flags.Bool("recover-targets", false, "Restore the targets from the last good backup if the targets file is corrupt")
+	_ = flags.Parse(args)
 
 	config, err := LoadConfig(*configFile)
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	onErrorCallbacks := make([]AlertFunc, 0)
-	onRecoverCallbacks := make([]AlertFunc, 0)
+	// Cancelled on SIGINT or SIGTERM, e.g. by docker stop
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	onErrorCallbacks = append(onErrorCallbacks, NewLogAlert())
-	onRecoverCallbacks = append(onRecoverCallbacks, NewLogResolve())
-
-	if config.SMTP != nil {
-		onErrorCallbacks = append(onErrorCallbacks, NewEmailAlert(*config.SMTP))
-		onRecoverCallbacks = append(onRecoverCallbacks, NewEmailAlert(*config.SMTP))
+	channels, err := NewNotificationChannels(config)
+	if err != nil {
+		log.Fatalf("Failed to create notification channels: %v", err)
+	}
+	notifications, err := NewNotificationQueue(channels, config.Notifications)
+	if err != nil {
+		log.Fatalf("Failed to create notification queue: %v", err)
 	}
+	notifications.Start()
 
-	checker, err := NewHealthChecker(time.Duration(config.CheckTimeoutInSec)*time.Second, config.TargetFile)
+	store, err := OpenTargetStore(ctx, config, *recoverTargets)
+	if err != nil {
+		log.Fatalf("Failed to open target store: %v", err)
+	}
+	checker, err := NewHealthChecker(time.Duration(config.CheckTimeoutInSec)*time.Second, store)
 	if err != nil {
 		log.Fatalf("Failed to create health checker: %v", err)
 	}
-	monitor := NewHealthMonitor(checker, time.Duration(config.CheckIntervalInSec)*time.Second, onErrorCallbacks, onRecoverCallbacks)
-	go monitor.Start()
+	configTargets, err := config.HealthTargets()
+	if err != nil {
+		log.Fatalf("Failed to load config targets: %v", err)
+	}
+	if err := checker.SetEgress(config.Egress); err != nil {
+		log.Fatalf("Failed to apply egress config: %v", err)
+	}
+	checker.SetConfigTargets(configTargets)
+	monitor := NewHealthMonitor(checker, time.Duration(config.CheckIntervalInSec)*time.Second, notifications)
+	monitor.Start(ctx)
+
+	auth, err := NewAuthenticator(config.Auth)
+	if err != nil {
+		log.Fatalf("Failed to set up authentication: %v", err)
+	}
+	if config.Auth == nil {
+		log.Printf("No auth configured, the API is open to everyone who can reach it")
+	}
+
+	reloader := NewConfigReloader(*configFile, config, checker, monitor, notifications, auth)
+	if *configFile != "" {
+		go reloader.Run(ctx)
+	}
 
 	// Create and setup server
 	router := http.NewServeMux()
-	server := &Server{checker: checker}
-	HandlerFromMux(server, router)
-	router.Handle("/metrics", promhttp.Handler())
+	server := NewServer(checker, monitor, notifications)
+	HandlerWithOptions(server, StdHTTPServerOptions{
+		BaseRouter:  router,
+		Middlewares: []MiddlewareFunc{auth.Middleware},
+	})
+	router.Handle("/metrics", auth.Metrics(promhttp.Handler()))
+	router.Handle("GET /badge/", auth.Badges(server.BadgeHandler()))
+	router.Handle("GET /ui/", DashboardHandler())
+	router.Handle("GET /{$}", http.RedirectHandler("/ui/", http.StatusFound))
+
+	var statusPage *StatusPage
+	var statusServer *http.Server
+	if config.StatusPage != nil {
+		statusPage, err = NewStatusPage(*config.StatusPage, monitor)
+		if err != nil {
+			log.Fatalf("Failed to create status page: %v", err)
+		}
+		go statusPage.Run(ctx)
+		server.SetStatusPage(statusPage)
+		statusServer = &http.Server{
+			Addr:    fmt.Sprintf(":%d", config.StatusPage.Port),
+			Handler: statusPage.Handler(),
+		}
+	}
+
+	// Requests still running once the grace period is over are cancelled through their context
+	requestCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+	httpServer := &http.Server{
+		Addr:        fmt.Sprintf(":%d", config.Port),
+		Handler:     router,
+		BaseContext: func(net.Listener) context.Context { return requestCtx },
+	}
+	httpServer.RegisterOnShutdown(monitor.CloseSubscriptions)
+	if config.TLS != nil {
+		files, err := newTLSFiles(*config.TLS)
+		if err != nil {
+			log.Fatalf("Failed to load TLS files: %v", err)
+		}
+		httpServer.TLSConfig = files.TLSConfig()
+	}
 
 	// Start server
 	log.Printf("Starting server on :%d", config.Port)
 	log.Printf("Prometheus metrics available at: /metrics")
-	if err := http.ListenAndServe(fmt.Sprintf(":%d", config.Port), router); err != nil {
+	log.Printf("Dashboard available at: /ui/")
+	serverErr := make(chan error, 2)
+	go func() {
+		if httpServer.TLSConfig != nil {
+			// The certificate comes from the TLS config, so it can be rotated
+			serverErr <- httpServer.ListenAndServeTLS("", "")
+		} else {
+			serverErr <- httpServer.ListenAndServe()
+		}
+	}()
+	if statusServer != nil {
+		log.Printf("Status page available on :%d", config.StatusPage.Port)
+		go func() {
+			serverErr <- statusServer.ListenAndServe()
+		}()
+	}
+
+	select {
+	case err := <-serverErr:
 		log.Fatalf("Server failed to start: %v", err)
+	case <-ctx.Done():
 	}
+	stop() // a second signal kills the process immediately
+
+	gracePeriod := time.Duration(reloader.Current().ShutdownGracePeriodInSec) * time.Second
+	log.Printf("Shutting down, waiting up to %s for in-progress work", gracePeriod)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+	defer cancel()
+
+	// Stop accepting requests first so no targets get registered after the store is closed
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		cancelRequests()
+		log.Printf("Failed to drain HTTP requests: %v", err)
+	}
+	if statusServer != nil {
+		if err := statusServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Failed to shut down the status page: %v", err)
+		}
+	}
+	if err := monitor.Stop(shutdownCtx); err != nil {
+		log.Printf("Cancelled in-progress health checks: %v", err)
+	}
+	if err := notifications.Stop(shutdownCtx); err != nil {
+		log.Printf("Pending notifications were not delivered and stay queued: %v", err)
+	}
+	if statusPage != nil {
+		if err := statusPage.Save(); err != nil {
+			log.Printf("Failed to save status page data: %v", err)
+		}
+	}
+	if store != nil {
+		if err := store.Close(); err != nil {
+			log.Printf("Failed to close target store: %v", err)
+		}
+	}
+	log.Printf("Shutdown complete")
+}
+
+// notifyTest sends a synthetic DOWN/RESOLVED pair through the configured channels
+// and returns a non-zero exit code if any delivery failed
+func notifyTest(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("notify-test", flag.ExitOnError)
+	configFile := flags.String("config", "", "Path to config file")
+	channel := flags.String("channel", "", "Only test the given channel, e.g. email or telegram")
+	_ = flags.Parse(args)
+
+	config, err := LoadConfig(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	channels, err := NewNotificationChannels(config)
+	if err != nil {
+		log.Fatalf("Failed to create notification channels: %v", err)
+	}
+	if *channel != "" {
+		filtered := channels[:0]
+		for _, c := range channels {
+			if c.Name == *channel {
+				filtered = append(filtered, c)
+			}
+		}
+		if len(filtered) == 0 {
+			log.Fatalf("Notification channel %q is not configured", *channel)
+		}
+		channels = filtered
+	}
+
+	exitCode := 0
+	for _, result := range SendTestNotifications(channels) {
+		if result.Error != nil {
+			fmt.Fprintf(out, "FAIL  %-10s %-8s %v\n", result.Channel, result.Kind, result.Error)
+			exitCode = 1
+		} else {
+			fmt.Fprintf(out, "OK    %-10s %-8s\n", result.Channel, result.Kind)
+		}
+	}
+	return exitCode
+}
+
+// validateConfig loads the config file and reports every problem found
+func validateConfig(args []string) int {
+	flags := flag.NewFlagSet("validate-config", flag.ExitOnError)
+	configFile := flags.String("config", "", "Path to config file")
+	_ = flags.Parse(args)
+	if *configFile == "" && flags.NArg() > 0 {
+		*configFile = flags.Arg(0)
+	}
+	if *configFile == "" {
+		fmt.Fprintln(os.Stderr, "Usage: doctor validate-config -config <path>")
+		return 2
+	}
+	if _, err := os.Stat(*configFile); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	if _, err := LoadConfig(*configFile); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	fmt.Printf("%s is valid\n", *configFile)
+	return 0
 }
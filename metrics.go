@@ -10,25 +10,66 @@ var (
 		Name:    "url_health_check_duration_seconds",
 		Help:    "Duration of health check requests in seconds",
 		Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
-	}, []string{"target_id", "url"})
+	}, []string{"namespace", "target_id", "url"})
 
 	healthCheckStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "url_health_check_status",
 		Help: "Status of health check (1 for healthy, 0 for unhealthy)",
-	}, []string{"target_id", "url"})
+	}, []string{"namespace", "target_id", "url"})
 
 	healthCheckStatusCode = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "url_health_check_status_code",
 		Help: "HTTP status code from health check",
-	}, []string{"target_id", "url"})
+	}, []string{"namespace", "target_id", "url"})
 
 	healthCheckErrors = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "url_health_check_errors_total",
 		Help: "Total number of health check errors",
-	}, []string{"target_id", "url", "error_type"})
+	}, []string{"namespace", "target_id", "url", "error_type"})
 
-	registeredTargets = promauto.NewGauge(prometheus.GaugeOpts{
+	registeredTargets = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "url_registered_targets_total",
 		Help: "Total number of registered targets",
+	}, []string{"namespace"})
+
+	notificationsDelivered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "doctor_notifications_delivered_total",
+		Help: "Total number of successfully delivered notifications",
+	}, []string{"channel", "kind"})
+
+	notificationDeliveryFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "doctor_notification_delivery_failures_total",
+		Help: "Total number of failed notification delivery attempts",
+	}, []string{"channel", "kind"})
+
+	notificationsDeadLettered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "doctor_notifications_dead_lettered_total",
+		Help: "Total number of notifications given up on after all retries",
+	}, []string{"channel", "kind"})
+
+	notificationQueueLength = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "doctor_notification_queue_length",
+		Help: "Number of notifications waiting for delivery",
+	}, []string{"channel"})
+
+	notificationDeliveryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "doctor_notification_delivery_duration_seconds",
+		Help:    "Duration of notification delivery attempts in seconds",
+		Buckets: []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"channel"})
+
+	configReloads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "doctor_config_reloads_total",
+		Help: "Total number of config reloads by result",
+	}, []string{"result"})
+
+	configLastReloadSuccessful = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "doctor_config_last_reload_successful",
+		Help: "Whether the last config reload succeeded (1) or the config was rejected (0)",
+	})
+
+	configLastReloadSuccessTimestamp = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "doctor_config_last_reload_success_timestamp_seconds",
+		Help: "Timestamp of the last successful config reload",
 	})
 )
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+// The default namespace is the empty string. It holds the targets of single-tenant
+// setups, so their target IDs and store keys stay the same.
+var namespaceRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// NamespaceConfig declares the targets and notification channels of a namespace. The
+// alerts of its targets only go to its own channels, never to the global ones.
+type NamespaceConfig struct {
+	Name     string          `json:"name"`
+	Targets  []TargetConfig  `json:"targets,omitempty"`
+	SMTP     *EmailConfig    `json:"smtp,omitempty"`
+	Telegram *TelegramConfig `json:"telegram,omitempty"`
+}
+
+// validateNamespace checks that a namespace name is a DNS label, the empty default
+// namespace is valid as well
+func validateNamespace(namespace string) error {
+	if namespace != "" && !namespaceRegexp.MatchString(namespace) {
+		return fmt.Errorf("invalid namespace %q, it must consist of lower case letters, digits and '-'", namespace)
+	}
+	return nil
+}
+
+// targetKey identifies a target across namespaces. Target IDs can't contain a slash,
+// so keys of different namespaces can't collide.
+func targetKey(namespace, id string) string {
+	if namespace == "" {
+		return id
+	}
+	return namespace + "/" + id
+}
+
+// Key identifies the target across namespaces
+func (t HealthTarget) Key() string {
+	return targetKey(t.Namespace, t.ID)
+}
+
+// requestNamespace returns the namespace a request operates in. Clients bound to a
+// namespace can only use their own, everyone else chooses one with the X-Namespace
+// header and uses the default namespace without it.
+func requestNamespace(r *http.Request) (string, *ApiError) {
+	namespace := r.Header.Get("X-Namespace")
+	if principal, ok := PrincipalFromContext(r.Context()); ok && principal.Namespace != "" {
+		if namespace != "" && namespace != principal.Namespace {
+			return "", ErrForbidden(fmt.Sprintf("%s may only access the %s namespace", principal.Name, principal.Namespace), nil)
+		}
+		return principal.Namespace, nil
+	}
+
+	if err := validateNamespace(namespace); err != nil {
+		return "", ErrInvalidNamespace(err.Error(), err)
+	}
+	return namespace, nil
+}
@@ -0,0 +1,583 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gitlab.com/tozd/go/errors"
+)
+
+// NotificationKind distinguishes alerts from resolution notices
+type NotificationKind string
+
+const (
+	NotificationAlert   NotificationKind = "alert"
+	NotificationResolve NotificationKind = "resolve"
+)
+
+// maxDeadLetters limits how many failed notifications are kept for inspection
+const maxDeadLetters = 1000
+
+// NotificationChannel is a named destination for alerts and resolution notices.
+// Either function may be nil if the channel doesn't support that kind.
+type NotificationChannel struct {
+	Name      string // unique across namespaces, prefixed with the namespace
+	Namespace string // only targets of this namespace are notified through the channel
+	Alert     AlertFunc
+	Resolve   AlertFunc
+}
+
+func (c NotificationChannel) funcFor(kind NotificationKind) AlertFunc {
+	if kind == NotificationResolve {
+		return c.Resolve
+	}
+	return c.Alert
+}
+
+// NotificationConfig configures delivery retries and queue persistence
+type NotificationConfig struct {
+	QueueFile           string `json:"queueFile,omitempty"`
+	MaxAttempts         int    `json:"maxAttempts,omitempty"`
+	InitialBackoffInSec int    `json:"initialBackoffInSec,omitempty"`
+	MaxBackoffInSec     int    `json:"maxBackoffInSec,omitempty"`
+}
+
+func (c NotificationConfig) withDefaults() NotificationConfig {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 5
+	}
+	if c.InitialBackoffInSec <= 0 {
+		c.InitialBackoffInSec = 5
+	}
+	if c.MaxBackoffInSec < c.InitialBackoffInSec {
+		c.MaxBackoffInSec = max(300, c.InitialBackoffInSec)
+	}
+	return c
+}
+
+// Notification is a single delivery of a health check result to a channel
+type Notification struct {
+	ID          string           `json:"id"`
+	Channel     string           `json:"channel"`
+	Kind        NotificationKind `json:"kind"`
+	Target      HealthTarget     `json:"target"`
+	Status      int              `json:"status"`
+	Healthy     bool             `json:"healthy"`
+	Timestamp   time.Time        `json:"timestamp"`
+	Duration    time.Duration    `json:"duration"`
+	Error       string           `json:"error,omitempty"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	Attempts    int              `json:"attempts"`
+	NextAttempt time.Time        `json:"nextAttempt"`
+	LastError   string           `json:"lastError,omitempty"`
+	FailedAt    time.Time        `json:"failedAt"`
+}
+
+// result reconstructs the health check result the notification was created from
+func (n Notification) result() Result {
+	target := n.Target
+	if target.URL == nil {
+		target.URL, _ = url.Parse(target.URLString)
+	}
+
+	result := Result{
+		Target:    target,
+		Status:    n.Status,
+		Healthy:   n.Healthy,
+		Timestamp: n.Timestamp,
+		Duration:  n.Duration,
+	}
+	if n.Error != "" {
+		result.Error = errors.New(n.Error)
+	}
+	return result
+}
+
+type channelQueue struct {
+	channel NotificationChannel
+	pending []Notification
+	wake    chan struct{}
+	removed chan struct{} // closed when the channel is removed on config reload
+}
+
+func newChannelQueue(channel NotificationChannel) *channelQueue {
+	notificationQueueLength.WithLabelValues(channel.Name).Set(0)
+	return &channelQueue{channel: channel, wake: make(chan struct{}, 1), removed: make(chan struct{})}
+}
+
+type queueFile struct {
+	Pending []Notification `json:"pending"`
+	Failed  []Notification `json:"failed"`
+}
+
+// NotificationQueue delivers notifications asynchronously with one worker per channel.
+// Failed deliveries are retried with exponential backoff and moved to a dead-letter
+// list once all attempts are exhausted. Notifications of a channel are delivered in order.
+type NotificationQueue struct {
+	mu          sync.Mutex
+	channels    map[string]*channelQueue
+	deadLetters []Notification
+	config      NotificationConfig
+	started     bool
+	drainChan   chan struct{}
+	// stopCtx is cancelled once the grace period of Stop is over, which abandons
+	// deliveries in progress
+	stopCtx   context.Context
+	stop      context.CancelFunc
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+	idCounter atomic.Uint64
+}
+
+// NewNotificationQueue creates a new NotificationQueue and restores persisted notifications
+func NewNotificationQueue(channels []NotificationChannel, config NotificationConfig) (*NotificationQueue, error) {
+	q := &NotificationQueue{
+		channels:  make(map[string]*channelQueue, len(channels)),
+		config:    config.withDefaults(),
+		drainChan: make(chan struct{}),
+	}
+	q.stopCtx, q.stop = context.WithCancel(context.Background())
+	for _, channel := range channels {
+		q.channels[channel.Name] = newChannelQueue(channel)
+	}
+
+	if config.QueueFile != "" {
+		if err := q.load(); err != nil {
+			return nil, errors.Wrap(err, "failed to load notification queue")
+		}
+	}
+
+	return q, nil
+}
+
+// Start launches one delivery worker per channel
+func (q *NotificationQueue) Start() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.started = true
+	for _, cq := range q.channels {
+		q.wg.Add(1)
+		go q.work(cq)
+	}
+}
+
+// Stop drains the queue, waiting for the workers to deliver all pending notifications.
+// Notifications waiting for a retry are not waited for. If ctx expires first, the
+// deliveries in progress are abandoned without waiting for them, and the remaining
+// notifications stay persisted for the next start.
+func (q *NotificationQueue) Stop(ctx context.Context) error {
+	q.stopOnce.Do(func() { close(q.drainChan) })
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		q.stop()
+		return ctx.Err()
+	}
+}
+
+// Enqueue schedules the result for delivery on every channel of the target's
+// namespace supporting the kind
+func (q *NotificationQueue) Enqueue(kind NotificationKind, result Result) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	for name, cq := range q.channels {
+		if cq.channel.Namespace != result.Target.Namespace || cq.channel.funcFor(kind) == nil {
+			continue
+		}
+
+		n := Notification{
+			ID:          fmt.Sprintf("%d-%d", now.UnixNano(), q.idCounter.Add(1)),
+			Channel:     name,
+			Kind:        kind,
+			Target:      result.Target,
+			Status:      result.Status,
+			Healthy:     result.Healthy,
+			Timestamp:   result.Timestamp,
+			Duration:    result.Duration,
+			CreatedAt:   now,
+			NextAttempt: now,
+		}
+		if result.Error != nil {
+			n.Error = result.Error.Error()
+		}
+
+		cq.pending = append(cq.pending, n)
+		notificationQueueLength.WithLabelValues(name).Set(float64(len(cq.pending)))
+		select {
+		case cq.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	q.persist()
+}
+
+// Channels returns the channels notifications are delivered to
+func (q *NotificationQueue) Channels() []NotificationChannel {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	channels := make([]NotificationChannel, 0, len(q.channels))
+	for _, cq := range q.channels {
+		channels = append(channels, cq.channel)
+	}
+	slices.SortFunc(channels, func(a, b NotificationChannel) int { return strings.Compare(a.Name, b.Name) })
+	return channels
+}
+
+// SetChannels replaces the notification channels. Pending notifications of
+// removed channels are moved to the dead-letter list.
+func (q *NotificationQueue) SetChannels(channels []NotificationChannel) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	names := make(map[string]bool, len(channels))
+	for _, channel := range channels {
+		names[channel.Name] = true
+		if cq, ok := q.channels[channel.Name]; ok {
+			cq.channel = channel
+			continue
+		}
+
+		cq := newChannelQueue(channel)
+		q.channels[channel.Name] = cq
+		if q.started {
+			q.wg.Add(1)
+			go q.work(cq)
+		}
+	}
+
+	for name, cq := range q.channels {
+		if names[name] {
+			continue
+		}
+		for _, n := range cq.pending {
+			n.LastError = "channel was removed from the config"
+			n.FailedAt = time.Now()
+			q.deadLetters = append(q.deadLetters, n)
+		}
+		cq.pending = nil
+		close(cq.removed)
+		delete(q.channels, name)
+		notificationQueueLength.DeleteLabelValues(name)
+	}
+
+	q.persist()
+}
+
+// SetConfig applies new retry settings, the queue file can't be changed at runtime
+func (q *NotificationQueue) SetConfig(config NotificationConfig) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	config.QueueFile = q.config.QueueFile
+	q.config = config.withDefaults()
+}
+
+// DeadLetters returns the notifications that could not be delivered, newest last
+func (q *NotificationQueue) DeadLetters() []Notification {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	deadLetters := make([]Notification, len(q.deadLetters))
+	copy(deadLetters, q.deadLetters)
+	return deadLetters
+}
+
+func (q *NotificationQueue) work(cq *channelQueue) {
+	defer q.wg.Done()
+
+	for {
+		q.mu.Lock()
+		if len(cq.pending) == 0 {
+			q.mu.Unlock()
+			select {
+			case <-cq.wake:
+				continue
+			case <-q.drainChan:
+				return
+			case <-q.stopCtx.Done():
+				return
+			case <-cq.removed:
+				return
+			}
+		}
+		n := cq.pending[0]
+		channel := cq.channel
+		q.mu.Unlock()
+
+		if wait := time.Until(n.NextAttempt); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-q.drainChan:
+				// Left in the queue file for the next start
+				timer.Stop()
+				return
+			case <-q.stopCtx.Done():
+				timer.Stop()
+				return
+			case <-cq.removed:
+				timer.Stop()
+				return
+			}
+		}
+
+		err := q.deliver(q.stopCtx, channel, n)
+		if q.stopCtx.Err() != nil {
+			// Abandoned on shutdown, it stays queued and may be delivered twice
+			return
+		}
+
+		q.mu.Lock()
+		if len(cq.pending) == 0 || cq.pending[0].ID != n.ID {
+			// The channel was removed while delivering
+			q.mu.Unlock()
+			continue
+		}
+		n.Attempts++
+		if err == nil {
+			cq.pending = cq.pending[1:]
+			notificationsDelivered.WithLabelValues(cq.channel.Name, string(n.Kind)).Inc()
+		} else {
+			slog.Error("notification delivery failed", "channel", cq.channel.Name, "target", n.Target.ID, "attempt", n.Attempts, "error", err)
+			notificationDeliveryFailures.WithLabelValues(cq.channel.Name, string(n.Kind)).Inc()
+			n.LastError = err.Error()
+
+			if n.Attempts >= q.config.MaxAttempts {
+				cq.pending = cq.pending[1:]
+				n.FailedAt = time.Now()
+				q.deadLetters = append(q.deadLetters, n)
+				if len(q.deadLetters) > maxDeadLetters {
+					q.deadLetters = q.deadLetters[len(q.deadLetters)-maxDeadLetters:]
+				}
+				notificationsDeadLettered.WithLabelValues(cq.channel.Name, string(n.Kind)).Inc()
+			} else {
+				n.NextAttempt = time.Now().Add(q.backoff(n.Attempts))
+				cq.pending[0] = n
+			}
+		}
+		notificationQueueLength.WithLabelValues(cq.channel.Name).Set(float64(len(cq.pending)))
+		q.persist()
+		q.mu.Unlock()
+	}
+}
+
+// deliver sends n through channel. Channels can't be interrupted, so if ctx is done
+// first, the delivery is left running in the background and ctx's error is returned.
+func (q *NotificationQueue) deliver(ctx context.Context, channel NotificationChannel, n Notification) error {
+	fn := channel.funcFor(n.Kind)
+	if fn == nil {
+		return errors.Errorf("channel %s does not support %s notifications", channel.Name, n.Kind)
+	}
+
+	startTime := time.Now()
+	result := n.result()
+	delivered := make(chan error, 1)
+	go func() { delivered <- fn(result.Target, result) }()
+	select {
+	case err := <-delivered:
+		notificationDeliveryDuration.WithLabelValues(channel.Name).Observe(time.Since(startTime).Seconds())
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// backoff returns the delay before the next attempt, doubling with every failed attempt
+func (q *NotificationQueue) backoff(attempts int) time.Duration {
+	delay := time.Duration(q.config.InitialBackoffInSec) * time.Second
+	maxDelay := time.Duration(q.config.MaxBackoffInSec) * time.Second
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// persist writes the queue to the queue file, it must be called with q.mu held. Errors
+// are logged, delivery goes on with the queue in memory.
+func (q *NotificationQueue) persist() {
+	if q.config.QueueFile == "" {
+		return
+	}
+
+	state := queueFile{Pending: make([]Notification, 0), Failed: q.deadLetters}
+	for _, cq := range q.channels {
+		state.Pending = append(state.Pending, cq.pending...)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		slog.Error("failed to marshal notification queue", "error", err)
+		return
+	}
+	// Atomically, so a crash while writing can't lose the whole queue
+	if err := writeFileAtomic(q.config.QueueFile, data, 0o644); err != nil {
+		slog.Error("failed to write notification queue", "path", q.config.QueueFile, "error", err)
+	}
+}
+
+func (q *NotificationQueue) load() error {
+	data, err := os.ReadFile(q.config.QueueFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to read notification queue file")
+	}
+
+	var state queueFile
+	if err := json.Unmarshal(data, &state); err != nil {
+		return errors.Wrap(err, "failed to unmarshal notification queue")
+	}
+
+	q.deadLetters = state.Failed
+	for _, n := range state.Pending {
+		cq, ok := q.channels[n.Channel]
+		if !ok {
+			n.LastError = "channel is no longer configured"
+			n.FailedAt = time.Now()
+			q.deadLetters = append(q.deadLetters, n)
+			continue
+		}
+		cq.pending = append(cq.pending, n)
+		notificationQueueLength.WithLabelValues(n.Channel).Set(float64(len(cq.pending)))
+	}
+
+	return nil
+}
+
+// NewNotificationChannels creates the notification channels enabled in the config.
+// Every namespace gets its own log channel and the channels configured for it.
+func NewNotificationChannels(config *Config) ([]NotificationChannel, error) {
+	channels, err := newNotificationChannels("", config.SMTP, config.Telegram)
+	if err != nil {
+		return nil, err
+	}
+	for _, namespace := range config.Namespaces {
+		namespaceChannels, err := newNotificationChannels(namespace.Name, namespace.SMTP, namespace.Telegram)
+		if err != nil {
+			return nil, fmt.Errorf("namespace %s: %w", namespace.Name, err)
+		}
+		channels = append(channels, namespaceChannels...)
+	}
+	return channels, nil
+}
+
+func newNotificationChannels(namespace string, smtp *EmailConfig, telegram *TelegramConfig) ([]NotificationChannel, error) {
+	channels := []NotificationChannel{
+		{Name: channelName(namespace, "log"), Namespace: namespace, Alert: NewLogAlert(), Resolve: NewLogResolve()},
+	}
+
+	if smtp != nil {
+		channels = append(channels, NotificationChannel{
+			Name:      channelName(namespace, "email"),
+			Namespace: namespace,
+			Alert:     NewEmailAlert(*smtp),
+			Resolve:   NewEmailResolve(*smtp),
+		})
+	}
+
+	if telegram != nil {
+		alerter, err := NewTelegramAlerter(*telegram)
+		if err != nil {
+			return nil, err
+		}
+		resolver, err := NewTelegramResolver(*telegram)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, NotificationChannel{
+			Name:      channelName(namespace, "telegram"),
+			Namespace: namespace,
+			Alert:     alerter,
+			Resolve:   resolver,
+		})
+	}
+
+	return channels, nil
+}
+
+// channelName prefixes the name of a channel of a namespace, the channels of the
+// default namespace keep their plain names
+func channelName(namespace, name string) string {
+	return targetKey(namespace, name)
+}
+
+// ChannelTestResult is the outcome of sending a test notification to a channel
+type ChannelTestResult struct {
+	Channel string
+	Kind    NotificationKind
+	Error   error
+}
+
+// SendTestNotifications sends a synthetic DOWN alert followed by a RESOLVED notice
+// directly through every channel, bypassing the queue so errors are reported immediately.
+func SendTestNotifications(channels []NotificationChannel) []ChannelTestResult {
+	urlString := "https://doctor.invalid/notify-test"
+	parsedURL, _ := url.Parse(urlString)
+	target := HealthTarget{
+		URL:       parsedURL,
+		URLString: urlString,
+		// A unique ID per run keeps throttling channels from swallowing repeated tests
+		ID: fmt.Sprintf("notify-test-%d", time.Now().Unix()),
+	}
+
+	down := Result{
+		Target:    target,
+		Status:    http.StatusServiceUnavailable,
+		Healthy:   false,
+		Timestamp: time.Now(),
+		Duration:  420 * time.Millisecond,
+		Error:     fmt.Errorf("synthetic failure sent by the notification test"),
+	}
+	resolved := Result{
+		Target:    target,
+		Status:    http.StatusOK,
+		Healthy:   true,
+		Timestamp: time.Now(),
+		Duration:  42 * time.Millisecond,
+	}
+
+	results := make([]ChannelTestResult, 0, 2*len(channels))
+	for _, channel := range channels {
+		for _, kind := range []NotificationKind{NotificationAlert, NotificationResolve} {
+			fn := channel.funcFor(kind)
+			if fn == nil {
+				continue
+			}
+
+			result := down
+			if kind == NotificationResolve {
+				result = resolved
+			}
+			results = append(results, ChannelTestResult{
+				Channel: channel.Name,
+				Kind:    kind,
+				Error:   fn(target, result),
+			})
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testResult(healthy bool) Result {
+	urlString := "https://example.com"
+	u, _ := url.Parse(urlString)
+	target := HealthTarget{URL: u, URLString: urlString, ID: "example"}
+	status := 200
+	if !healthy {
+		status = 503
+	}
+	return Result{Target: target, Status: status, Healthy: healthy, Timestamp: time.Now()}
+}
+
+func waitFor(t *testing.T, timeout time.Duration, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestNotificationQueue(t *testing.T) {
+	t.Run("Retries until delivery succeeds", func(t *testing.T) {
+		var calls atomic.Int32
+		channel := NotificationChannel{Name: "flaky", Alert: func(target HealthTarget, result Result) error {
+			if calls.Add(1) == 1 {
+				return errors.New("temporarily unavailable")
+			}
+			return nil
+		}}
+
+		q, err := NewNotificationQueue([]NotificationChannel{channel}, NotificationConfig{MaxAttempts: 3, InitialBackoffInSec: 1})
+		if err != nil {
+			t.Fatalf("Failed to create queue: %v", err)
+		}
+		q.Start()
+		defer q.Stop(context.Background())
+
+		q.Enqueue(NotificationAlert, testResult(false))
+		waitFor(t, 3*time.Second, func() bool { return calls.Load() == 2 })
+
+		if len(q.DeadLetters()) != 0 {
+			t.Fatalf("Expected no dead letters, got %v", q.DeadLetters())
+		}
+	})
+
+	t.Run("Moves exhausted notifications to the dead-letter list", func(t *testing.T) {
+		channel := NotificationChannel{Name: "broken", Alert: func(target HealthTarget, result Result) error {
+			return errors.New("connection refused")
+		}}
+
+		q, err := NewNotificationQueue([]NotificationChannel{channel}, NotificationConfig{MaxAttempts: 1})
+		if err != nil {
+			t.Fatalf("Failed to create queue: %v", err)
+		}
+		q.Start()
+		defer q.Stop(context.Background())
+
+		q.Enqueue(NotificationAlert, testResult(false))
+		q.Enqueue(NotificationResolve, testResult(true)) // not supported by the channel
+		waitFor(t, time.Second, func() bool { return len(q.DeadLetters()) == 1 })
+
+		failed := q.DeadLetters()[0]
+		if failed.Channel != "broken" || failed.Kind != NotificationAlert || failed.LastError != "connection refused" {
+			t.Fatalf("Unexpected dead letter: %+v", failed)
+		}
+	})
+
+	t.Run("Restores pending notifications from the queue file", func(t *testing.T) {
+		config := NotificationConfig{QueueFile: filepath.Join(t.TempDir(), "queue.json")}
+		var delivered atomic.Int32
+		channel := NotificationChannel{Name: "email", Alert: func(target HealthTarget, result Result) error {
+			if target.URL == nil || target.URL.Host != "example.com" || result.Healthy {
+				return errors.New("unexpected result")
+			}
+			delivered.Add(1)
+			return nil
+		}}
+
+		// Enqueue without starting the workers, as if the process died before delivery
+		q, err := NewNotificationQueue([]NotificationChannel{channel}, config)
+		if err != nil {
+			t.Fatalf("Failed to create queue: %v", err)
+		}
+		q.Enqueue(NotificationAlert, testResult(false))
+
+		restored, err := NewNotificationQueue([]NotificationChannel{channel}, config)
+		if err != nil {
+			t.Fatalf("Failed to restore queue: %v", err)
+		}
+		restored.Start()
+		defer restored.Stop(context.Background())
+
+		waitFor(t, time.Second, func() bool { return delivered.Load() == 1 })
+	})
+	t.Run("Only notifies the channels of the target's namespace", func(t *testing.T) {
+		var global, payments, search atomic.Int32
+		count := func(counter *atomic.Int32) AlertFunc {
+			return func(HealthTarget, Result) error {
+				counter.Add(1)
+				return nil
+			}
+		}
+		q, err := NewNotificationQueue([]NotificationChannel{
+			{Name: "email", Alert: count(&global)},
+			{Name: "payments/email", Namespace: "payments", Alert: count(&payments)},
+			{Name: "search/email", Namespace: "search", Alert: count(&search)},
+		}, NotificationConfig{})
+		if err != nil {
+			t.Fatalf("Failed to create queue: %v", err)
+		}
+		q.Start()
+		defer q.Stop(context.Background())
+
+		result := testResult(false)
+		result.Target.Namespace = "payments"
+		q.Enqueue(NotificationAlert, result)
+		waitFor(t, time.Second, func() bool { return payments.Load() == 1 })
+
+		if global.Load() != 0 || search.Load() != 0 {
+			t.Fatalf("Expected only the payments channel to be notified, got global %d and search %d", global.Load(), search.Load())
+		}
+	})
+
+	t.Run("Replaces the queue file atomically", func(t *testing.T) {
+		dir := t.TempDir()
+		q, err := NewNotificationQueue([]NotificationChannel{{Name: "log", Alert: NewLogAlert()}}, NotificationConfig{QueueFile: filepath.Join(dir, "queue.json")})
+		if err != nil {
+			t.Fatalf("Failed to create queue: %v", err)
+		}
+		q.Enqueue(NotificationAlert, testResult(false))
+		q.Enqueue(NotificationAlert, testResult(false))
+
+		entries, _ := os.ReadDir(dir)
+		if len(entries) != 1 || entries[0].Name() != "queue.json" {
+			t.Fatalf("Expected only the queue file without temporary files, got %v", entries)
+		}
+		restored, err := NewNotificationQueue([]NotificationChannel{{Name: "log", Alert: NewLogAlert()}}, NotificationConfig{QueueFile: filepath.Join(dir, "queue.json")})
+		if err != nil || len(restored.channels["log"].pending) != 2 {
+			t.Fatalf("Expected 2 pending notifications, got %v", err)
+		}
+	})
+
+	t.Run("Stops without waiting for a retry", func(t *testing.T) {
+		config := NotificationConfig{QueueFile: filepath.Join(t.TempDir(), "queue.json"), MaxAttempts: 3, InitialBackoffInSec: 60}
+		var calls atomic.Int32
+		channel := NotificationChannel{Name: "broken", Alert: func(target HealthTarget, result Result) error {
+			calls.Add(1)
+			return errors.New("connection refused")
+		}}
+		q, err := NewNotificationQueue([]NotificationChannel{channel}, config)
+		if err != nil {
+			t.Fatalf("Failed to create queue: %v", err)
+		}
+		q.Start()
+		q.Enqueue(NotificationAlert, testResult(false))
+		waitFor(t, time.Second, func() bool { return calls.Load() == 1 })
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		start := time.Now()
+		if err := q.Stop(ctx); err != nil || time.Since(start) > time.Second {
+			t.Fatalf("Expected the queue to stop promptly, got %v after %s", err, time.Since(start))
+		}
+
+		restored, err := NewNotificationQueue([]NotificationChannel{channel}, config)
+		if err != nil {
+			t.Fatalf("Failed to restore queue: %v", err)
+		}
+		pending := restored.channels["broken"].pending
+		if len(pending) != 1 || pending[0].Attempts != 1 || pending[0].LastError != "connection refused" {
+			t.Fatalf("Expected the notification to stay queued, got %+v", pending)
+		}
+	})
+
+	t.Run("Abandons deliveries in progress after the grace period", func(t *testing.T) {
+		config := NotificationConfig{QueueFile: filepath.Join(t.TempDir(), "queue.json")}
+		blocked, release := make(chan struct{}), make(chan struct{})
+		t.Cleanup(func() { close(release) })
+		channel := NotificationChannel{Name: "stuck", Alert: func(target HealthTarget, result Result) error {
+			close(blocked)
+			<-release
+			return nil
+		}}
+		q, err := NewNotificationQueue([]NotificationChannel{channel}, config)
+		if err != nil {
+			t.Fatalf("Failed to create queue: %v", err)
+		}
+		q.Start()
+		q.Enqueue(NotificationAlert, testResult(false))
+		<-blocked
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		if err := q.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) || time.Since(start) > time.Second {
+			t.Fatalf("Expected the queue to stop after the grace period, got %v after %s", err, time.Since(start))
+		}
+		restored, err := NewNotificationQueue([]NotificationChannel{channel}, config)
+		if err != nil || len(restored.channels["stuck"].pending) != 1 {
+			t.Fatalf("Expected the abandoned notification to stay queued, got %v", err)
+		}
+	})
+}
+
+func TestSendTestNotifications(t *testing.T) {
+	smtpServer := newFakeSMTPServer(t, "", "")
+	smtp := smtpServer.config()
+	smtp.AuthType = AuthTypeNone
+	// Nothing listens on the port once the listener is closed
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	_, closedPort, _ := net.SplitHostPort(l.Addr().String())
+	l.Close()
+
+	t.Run("Sends a DOWN alert and a RESOLVED notice per channel", func(t *testing.T) {
+		results := SendTestNotifications([]NotificationChannel{
+			{Name: "email", Alert: NewEmailAlert(smtp), Resolve: NewEmailResolve(smtp)},
+			{Name: "pager", Alert: func(HealthTarget, Result) error { return errors.New("pager is down") }},
+		})
+		if len(results) != 3 {
+			t.Fatalf("Expected 3 results, got %+v", results)
+		}
+		if results[0].Kind != NotificationAlert || results[0].Error != nil || results[1].Kind != NotificationResolve || results[1].Error != nil {
+			t.Fatalf("Expected both emails to be sent, got %+v", results[:2])
+		}
+		if results[2].Channel != "pager" || results[2].Error == nil {
+			t.Fatalf("Expected the pager alert to fail, got %+v", results[2])
+		}
+		smtpServer.mu.Lock()
+		defer smtpServer.mu.Unlock()
+		if len(smtpServer.messages) != 2 || !strings.Contains(smtpServer.messages[0], "notify-test") {
+			t.Fatalf("Expected 2 test emails, got %v", smtpServer.messages)
+		}
+	})
+
+	t.Run("notify-test command", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.json")
+		config := fmt.Sprintf(`{
+			"smtp": {"from": %q, "smtpHost": %q, "smtpPort": %q, "toEmails": [%q], "authType": "none"},
+			"namespaces": [{"name": "team-a", "smtp": {"from": %q, "smtpHost": %q, "smtpPort": %q, "toEmails": [%q], "authType": "none"}}]
+		}`, smtp.From, smtp.SMTPHost, smtp.SMTPPort, smtp.ToEmails[0], smtp.From, smtp.SMTPHost, closedPort, smtp.ToEmails[0])
+		if err := os.WriteFile(configFile, []byte(config), 0o644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+
+		var out bytes.Buffer
+		if exitCode := notifyTest([]string{"-config", configFile, "-channel", "email"}, &out); exitCode != 0 {
+			t.Fatalf("Expected exit code 0, got %d: %s", exitCode, out.String())
+		}
+		if strings.Count(out.String(), "OK    email") != 2 || strings.Contains(out.String(), "team-a") {
+			t.Fatalf("Expected only the email channel to be tested, got %s", out.String())
+		}
+
+		out.Reset()
+		if exitCode := notifyTest([]string{"-config", configFile}, &out); exitCode != 1 {
+			t.Fatalf("Expected exit code 1, got %d: %s", exitCode, out.String())
+		}
+		if !strings.Contains(out.String(), "OK    log") || !strings.Contains(out.String(), "FAIL  team-a/email alert") {
+			t.Fatalf("Expected the team-a email to fail, got %s", out.String())
+		}
+	})
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+
+	"gitlab.com/tozd/go/errors"
+)
+
+// configPollInterval is how often the config file is checked for changes. Polling
+// instead of file system events also catches Kubernetes ConfigMap symlink swaps.
+const configPollInterval = 5 * time.Second
+
+// ConfigReloader re-reads the config file when it changes or on SIGHUP and applies
+// the difference to the running components. Invalid configs are rejected and the
+// running config is kept.
+type ConfigReloader struct {
+	path          string
+	checker       *HealthChecker
+	monitor       *HealthMonitor
+	notifications *NotificationQueue
+	auth          *Authenticator
+
+	mu      sync.Mutex
+	current *Config
+	data    []byte
+}
+
+// NewConfigReloader creates a new ConfigReloader for the config loaded from path
+func NewConfigReloader(
+	path string,
+	config *Config,
+	checker *HealthChecker,
+	monitor *HealthMonitor,
+	notifications *NotificationQueue,
+	auth *Authenticator,
+) *ConfigReloader {
+	data, _ := os.ReadFile(path)
+	configLastReloadSuccessful.Set(1)
+	configLastReloadSuccessTimestamp.SetToCurrentTime()
+
+	return &ConfigReloader{
+		path:          path,
+		checker:       checker,
+		monitor:       monitor,
+		notifications: notifications,
+		auth:          auth,
+		current:       config,
+		data:          data,
+	}
+}
+
+// Run watches the config file and SIGHUP until ctx is done
+func (r *ConfigReloader) Run(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			slog.Info("received SIGHUP, reloading config", "path", r.path)
+			_ = r.Reload()
+		case <-ticker.C:
+			if r.changed() {
+				slog.Info("config file changed, reloading", "path", r.path)
+				_ = r.Reload()
+			}
+		}
+	}
+}
+
+// Current returns the config currently in effect
+func (r *ConfigReloader) Current() *Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current
+}
+
+func (r *ConfigReloader) changed() bool {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return !bytes.Equal(data, r.data)
+}
+
+// Reload loads the config file and applies it
+func (r *ConfigReloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Unlike at startup, a missing or empty file never means the defaults. It's most
+	// likely being replaced, and the defaults would e.g. turn off authentication.
+	data, err := os.ReadFile(r.path)
+	if err == nil {
+		r.data = data // don't retry a rejected config until it changes again
+		if len(bytes.TrimSpace(data)) == 0 {
+			err = errors.New("config file is empty")
+		}
+	}
+
+	var config *Config
+	if err == nil {
+		var doc map[string]any
+		var locate configLocator
+		doc, locate, err = parseConfigDocument(r.path, data)
+		if err == nil {
+			config, err = loadConfigDocument(r.path, doc, locate)
+		}
+	}
+	if err == nil {
+		err = r.apply(config)
+	}
+	if err != nil {
+		slog.Error("rejected config reload, keeping the running config", "path", r.path, "error", err)
+		configReloads.WithLabelValues("failure").Inc()
+		configLastReloadSuccessful.Set(0)
+		return err
+	}
+
+	r.current = config
+	configReloads.WithLabelValues("success").Inc()
+	configLastReloadSuccessful.Set(1)
+	configLastReloadSuccessTimestamp.SetToCurrentTime()
+	slog.Info("config reloaded", "path", r.path)
+	return nil
+}
+
+// apply changes the running components to match config, it must be called with r.mu held.
+// Everything that can fail is done before the first component is changed.
+func (r *ConfigReloader) apply(config *Config) error {
+	old := r.current
+
+	targets, err := config.HealthTargets()
+	if err != nil {
+		return err
+	}
+
+	var channels []NotificationChannel
+	if !reflect.DeepEqual(old.SMTP, config.SMTP) || !reflect.DeepEqual(old.Telegram, config.Telegram) ||
+		!reflect.DeepEqual(old.Namespaces, config.Namespaces) {
+		channels, err = NewNotificationChannels(config)
+		if err != nil {
+			return errors.Wrap(err, "failed to create notification channels")
+		}
+	}
+
+	// Reading the JWKS file can fail, so the auth config is applied first
+	if !reflect.DeepEqual(old.Auth, config.Auth) {
+		if err := r.auth.SetConfig(config.Auth); err != nil {
+			return errors.Wrap(err, "failed to apply auth config")
+		}
+	}
+
+	if !reflect.DeepEqual(old.Egress, config.Egress) {
+		if err := r.checker.SetEgress(config.Egress); err != nil {
+			return errors.Wrap(err, "failed to apply egress config")
+		}
+	}
+	if config.CheckTimeoutInSec != old.CheckTimeoutInSec {
+		r.checker.SetTimeout(time.Duration(config.CheckTimeoutInSec) * time.Second)
+	}
+	if config.CheckIntervalInSec != old.CheckIntervalInSec {
+		r.monitor.SetInterval(time.Duration(config.CheckIntervalInSec) * time.Second)
+	}
+	if channels != nil {
+		r.notifications.SetChannels(channels)
+	}
+	if !reflect.DeepEqual(old.Targets, config.Targets) || !reflect.DeepEqual(old.Namespaces, config.Namespaces) {
+		r.checker.SetConfigTargets(targets)
+	}
+	if config.Notifications != old.Notifications {
+		r.notifications.SetConfig(config.Notifications)
+	}
+
+	for _, field := range restartRequired(old, config) {
+		slog.Warn("config change only takes effect after a restart", "field", field)
+	}
+
+	return nil
+}
+
+// restartRequired returns the changed fields that can't be applied at runtime
+func restartRequired(old, config *Config) []string {
+	var fields []string
+	if old.Port != config.Port {
+		fields = append(fields, "port")
+	}
+	if !reflect.DeepEqual(old.TLS, config.TLS) {
+		fields = append(fields, "tls")
+	}
+	if !reflect.DeepEqual(old.StatusPage, config.StatusPage) {
+		fields = append(fields, "statusPage")
+	}
+	if old.TargetFile != config.TargetFile {
+		fields = append(fields, "targetFile")
+	}
+	if !reflect.DeepEqual(old.TargetStore, config.TargetStore) {
+		fields = append(fields, "targetStore")
+	}
+	if old.Notifications.QueueFile != config.Notifications.QueueFile {
+		fields = append(fields, "notifications.queueFile")
+	}
+	return fields
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConfigReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeConfig := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+	}
+
+	writeConfig(`{"checkIntervalInSec": 30, "checkTimeoutInSec": 10}`)
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	checker, _ := NewHealthChecker(10*time.Second, nil)
+	notifications, _ := NewNotificationQueue(nil, config.Notifications)
+	monitor := NewHealthMonitor(checker, 30*time.Second, notifications)
+	reloader := NewConfigReloader(path, config, checker, monitor, notifications, mustAuthenticator(t, config.Auth))
+
+	t.Run("Rejects an invalid config and keeps the running one", func(t *testing.T) {
+		writeConfig(`{"checkIntervalInSec": -1}`)
+		if err := reloader.Reload(); err == nil {
+			t.Fatal("Expected the reload to fail")
+		}
+		if reloader.Current() != config {
+			t.Fatal("Expected the running config to be kept")
+		}
+	})
+
+	t.Run("Applies a valid config", func(t *testing.T) {
+		writeConfig(`{"checkIntervalInSec": 5, "checkTimeoutInSec": 2, "notifications": {"maxAttempts": 2}}`)
+		if err := reloader.Reload(); err != nil {
+			t.Fatalf("Failed to reload: %v", err)
+		}
+
+		if got := monitor.getInterval(); got != 5*time.Second {
+			t.Errorf("Expected interval of 5s, got %s", got)
+		}
+		if got := time.Duration(checker.timeout.Load()); got != 2*time.Second {
+			t.Errorf("Expected timeout of 2s, got %s", got)
+		}
+		if got := notifications.config.MaxAttempts; got != 2 {
+			t.Errorf("Expected 2 max attempts, got %d", got)
+		}
+	})
+}
+
+func TestConfigReloadWithoutFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "auth:\n  apiKeys:\n    - {name: ci, key: 0123456789abcdef, role: admin}\ntargets:\n  - {id: web, url: https://example.com}\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	targets, _ := config.HealthTargets()
+	checker, _ := NewHealthChecker(10*time.Second, nil)
+	checker.SetConfigTargets(targets)
+	notifications, _ := NewNotificationQueue(nil, config.Notifications)
+	monitor := NewHealthMonitor(checker, 30*time.Second, notifications)
+	auth := mustAuthenticator(t, config.Auth)
+	reloader := NewConfigReloader(path, config, checker, monitor, notifications, auth)
+
+	expectUnchanged := func() {
+		t.Helper()
+		if reloader.Current() != config || auth.config == nil || len(auth.keys) != 1 {
+			t.Fatalf("Expected the running config and auth to be kept")
+		}
+		if _, ok := checker.Target("web"); !ok {
+			t.Fatalf("Expected the config targets to be kept")
+		}
+	}
+
+	// Truncated while being rewritten
+	if err := os.WriteFile(path, []byte("\n"), 0644); err != nil {
+		t.Fatalf("Failed to truncate config: %v", err)
+	}
+	if err := reloader.Reload(); err == nil {
+		t.Fatal("Expected the reload of an empty file to fail")
+	}
+	expectUnchanged()
+
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("Failed to remove config: %v", err)
+	}
+	if err := reloader.Reload(); err == nil {
+		t.Fatal("Expected the reload of a missing file to fail")
+	}
+	expectUnchanged()
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+)
+
+// Output formats of doctor run
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatJUnit = "junit"
+)
+
+// roundResult is the outcome of checking a target in one round
+type roundResult struct {
+	Round           int     `json:"round"`
+	Healthy         bool    `json:"healthy"`
+	Status          int     `json:"status"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// failure describes why the check failed, empty if it was healthy
+func (r roundResult) failure() string {
+	switch {
+	case r.Healthy:
+		return ""
+	case r.Error != "":
+		return r.Error
+	default:
+		return fmt.Sprintf("status %d", r.Status)
+	}
+}
+
+// targetReport collects the results of a target over all rounds. A target only passes
+// if it was healthy in every round.
+type targetReport struct {
+	ID        string        `json:"id"`
+	Namespace string        `json:"namespace,omitempty"`
+	URL       string        `json:"url"`
+	Healthy   bool          `json:"healthy"`
+	Results   []roundResult `json:"results"`
+}
+
+func (t targetReport) passed() int {
+	passed := 0
+	for _, result := range t.Results {
+		if result.Healthy {
+			passed++
+		}
+	}
+	return passed
+}
+
+func (t targetReport) duration() float64 {
+	total := 0.0
+	for _, result := range t.Results {
+		total += result.DurationSeconds
+	}
+	return total
+}
+
+type runReport struct {
+	Healthy  bool           `json:"healthy"`
+	Rounds   int            `json:"rounds"`
+	Started  time.Time      `json:"started"`
+	Finished time.Time      `json:"finished"`
+	Targets  []targetReport `json:"targets"`
+}
+
+// runCommand checks the targets declared in the config a number of rounds without
+// starting the API server or sending notifications, and exits with 1 if a target was
+// unhealthy. Targets registered through the API are left out, their file or store may
+// be in use by a running Doctor.
+func runCommand(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	configFile := flags.String("config", "", "Path to config file, only the targets declared in it are checked, not the ones registered through the API")
+	once := flags.Bool("once", false, "Check all targets and exit, required")
+	rounds := flags.Int("rounds", 1, "Number of times every target is checked, a target fails if any round fails")
+	interval := flags.Duration("interval", 5*time.Second, "Time between rounds")
+	format := flags.String("format", formatTable, "Output format: table, json or junit")
+	output := flags.String("output", "", "Write the report to this file instead of stdout")
+	var labels listFlag
+	flags.Var(&labels, "label", "Only check targets matching the label selector, can be repeated")
+	_ = flags.Parse(args)
+
+	if !*once {
+		fmt.Fprintln(os.Stderr, "doctor run requires -once, use doctor serve to check continuously")
+		return 2
+	}
+	if *rounds < 1 {
+		fmt.Fprintln(os.Stderr, "-rounds must be at least 1")
+		return 2
+	}
+	if !slices.Contains([]string{formatTable, formatJSON, formatJUnit}, *format) {
+		fmt.Fprintf(os.Stderr, "Unknown format %q, use table, json or junit\n", *format)
+		return 2
+	}
+	selector, err := parseLabelSelector(labels)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	config, err := LoadConfig(*configFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	targets, err := config.HealthTargets()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	targets = slices.DeleteFunc(targets, func(target HealthTarget) bool { return !selector.Matches(target.Labels) })
+	if len(targets) == 0 {
+		fmt.Fprintln(os.Stderr, "No targets to check")
+		return 2
+	}
+	checker, err := NewHealthChecker(time.Duration(config.CheckTimeoutInSec)*time.Second, nil)
+	if err == nil {
+		err = checker.SetEgress(config.Egress)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	checker.SetConfigTargets(targets)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	report := runRounds(ctx, checker, *rounds, *interval)
+
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		err = writeReport(file, *format, report)
+		// A failed close can mean the report didn't make it to disk
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write report: %v\n", err)
+			return 2
+		}
+	} else if err := writeReport(out, *format, report); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write report: %v\n", err)
+		return 2
+	}
+	if !report.Healthy {
+		return 1
+	}
+	return 0
+}
+
+// runRounds checks all targets of the checker the given number of rounds. Rounds left
+// when ctx is cancelled are skipped and the report is unhealthy.
+func runRounds(ctx context.Context, checker *HealthChecker, rounds int, interval time.Duration) runReport {
+	report := runReport{Rounds: rounds, Started: time.Now()}
+	targets := make(map[string]*targetReport)
+	for round := 1; round <= rounds; round++ {
+		if round > 1 {
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		for _, result := range checker.CheckAll(ctx) {
+			key := result.Target.Key()
+			if targets[key] == nil {
+				targets[key] = &targetReport{ID: result.Target.ID, Namespace: result.Target.Namespace, URL: result.Target.URLString}
+			}
+			r := roundResult{Round: round, Healthy: result.Healthy, Status: result.Status, DurationSeconds: result.Duration.Seconds()}
+			if result.Error != nil {
+				r.Error = result.Error.Error()
+			}
+			targets[key].Results = append(targets[key].Results, r)
+		}
+	}
+	report.Finished = time.Now()
+
+	report.Healthy = ctx.Err() == nil
+	for _, key := range slices.Sorted(maps.Keys(targets)) {
+		target := targets[key]
+		target.Healthy = target.passed() == rounds
+		report.Healthy = report.Healthy && target.Healthy
+		report.Targets = append(report.Targets, *target)
+	}
+	return report
+}
+
+func writeReport(out io.Writer, format string, report runReport) error {
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case formatJUnit:
+		return writeJUnit(out, report)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "RESULT\tTARGET\tURL\tPASSED\tSTATUS\tDURATION\tERROR")
+	for _, target := range report.Targets {
+		result, last := "PASS", target.Results[len(target.Results)-1]
+		if !target.Healthy {
+			result = "FAIL"
+		}
+		var failure string
+		for _, r := range slices.Backward(target.Results) {
+			if failure = r.failure(); failure != "" {
+				break
+			}
+		}
+		duration := time.Duration(last.DurationSeconds * float64(time.Second)).Round(time.Millisecond)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d/%d\t%s\t%s\t%s\n",
+			result, targetKey(target.Namespace, target.ID), target.URL, target.passed(), len(target.Results),
+			formatStatusCode(last.Status), duration, failure)
+	}
+	return w.Flush()
+}
+
+// writeJUnit writes the report as JUnit XML, which CI systems show as test results.
+// Every target is a test case.
+func writeJUnit(out io.Writer, report runReport) error {
+	type failure struct {
+		Message string `xml:"message,attr"`
+		Text    string `xml:",chardata"`
+	}
+	type testCase struct {
+		Name      string   `xml:"name,attr"`
+		ClassName string   `xml:"classname,attr"`
+		Time      string   `xml:"time,attr"`
+		Failure   *failure `xml:"failure,omitempty"`
+	}
+	type testSuite struct {
+		XMLName   xml.Name   `xml:"testsuite"`
+		Name      string     `xml:"name,attr"`
+		Tests     int        `xml:"tests,attr"`
+		Failures  int        `xml:"failures,attr"`
+		Time      string     `xml:"time,attr"`
+		Timestamp string     `xml:"timestamp,attr"`
+		TestCases []testCase `xml:"testcase"`
+	}
+
+	suite := testSuite{
+		Name:      "doctor",
+		Tests:     len(report.Targets),
+		Time:      fmt.Sprintf("%.3f", report.Finished.Sub(report.Started).Seconds()),
+		Timestamp: report.Started.UTC().Format(time.RFC3339),
+	}
+	for _, target := range report.Targets {
+		// CI systems group test cases by class, one per namespace
+		tc := testCase{
+			Name:      targetKey(target.Namespace, target.ID),
+			ClassName: "doctor",
+			Time:      fmt.Sprintf("%.3f", target.duration()),
+		}
+		if target.Namespace != "" {
+			tc.ClassName += "." + target.Namespace
+		}
+		if !target.Healthy {
+			var lines []string
+			for _, r := range target.Results {
+				if f := r.failure(); f != "" {
+					lines = append(lines, fmt.Sprintf("round %d: %s", r.Round, f))
+				}
+			}
+			tc.Failure = &failure{
+				Message: fmt.Sprintf("%s was unhealthy in %d of %d rounds", target.URL, len(target.Results)-target.passed(), len(target.Results)),
+				Text:    strings.Join(lines, "\n"),
+			}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "%s\n", data)
+	return err
+}
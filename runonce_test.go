@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRunOnce(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(healthy.Close)
+	// Only fails the first request, so it fails one of two rounds
+	var requests atomic.Int32
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	t.Cleanup(flaky.Close)
+
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.json")
+	config := fmt.Sprintf(`{"targets": [
+		{"id": "web", "url": %q, "labels": {"tier": "frontend"}},
+		{"id": "api", "url": %q}
+	]}`, healthy.URL, flaky.URL)
+	if err := os.WriteFile(configFile, []byte(config), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	run := func(args ...string) (int, string) {
+		t.Helper()
+		var out bytes.Buffer
+		return runCommand(append([]string{"-config", configFile}, args...), &out), out.String()
+	}
+
+	t.Run("Requires -once", func(t *testing.T) {
+		if exitCode, _ := run(); exitCode != 2 {
+			t.Fatalf("Expected exit code 2, got %d", exitCode)
+		}
+	})
+
+	t.Run("Fails if a round fails", func(t *testing.T) {
+		exitCode, output := run("-once", "-rounds", "2", "-interval", "0", "-format", "json")
+		if exitCode != 1 {
+			t.Fatalf("Expected exit code 1, got %d: %s", exitCode, output)
+		}
+		var report runReport
+		if err := json.Unmarshal([]byte(output), &report); err != nil {
+			t.Fatalf("Failed to decode report: %v", err)
+		}
+		if report.Healthy || len(report.Targets) != 2 {
+			t.Fatalf("Expected an unhealthy report of 2 targets, got %+v", report)
+		}
+		api, web := report.Targets[0], report.Targets[1]
+		if api.ID != "api" || api.Healthy || api.passed() != 1 || api.Results[0].Status != http.StatusBadGateway {
+			t.Fatalf("Expected api to fail the first round, got %+v", api)
+		}
+		if web.ID != "web" || !web.Healthy || len(web.Results) != 2 {
+			t.Fatalf("Expected web to pass both rounds, got %+v", web)
+		}
+	})
+
+	t.Run("Filtered by label", func(t *testing.T) {
+		exitCode, output := run("-once", "-label", "tier=frontend")
+		if exitCode != 0 || !strings.Contains(output, "PASS") || strings.Contains(output, "api") {
+			t.Fatalf("Expected only web to pass, got %d: %s", exitCode, output)
+		}
+		if exitCode, _ := run("-once", "-label", "tier=backend"); exitCode != 2 {
+			t.Fatalf("Expected exit code 2 without targets, got %d", exitCode)
+		}
+	})
+
+	t.Run("Report that can't be written", func(t *testing.T) {
+		if _, err := os.Stat("/dev/full"); err != nil {
+			t.Skip("/dev/full is not available")
+		}
+		if exitCode, _ := run("-once", "-label", "tier=frontend", "-output", "/dev/full"); exitCode != 2 {
+			t.Fatalf("Expected exit code 2, got %d", exitCode)
+		}
+	})
+
+	t.Run("JUnit report", func(t *testing.T) {
+		requests.Store(0)
+		report := filepath.Join(dir, "report.xml")
+		if exitCode, _ := run("-once", "-format", "junit", "-output", report); exitCode != 1 {
+			t.Fatalf("Expected exit code 1, got %d", exitCode)
+		}
+		data, err := os.ReadFile(report)
+		if err != nil {
+			t.Fatalf("Failed to read report: %v", err)
+		}
+		var suite struct {
+			Tests     int `xml:"tests,attr"`
+			Failures  int `xml:"failures,attr"`
+			TestCases []struct {
+				Name    string `xml:"name,attr"`
+				Failure *struct {
+					Text string `xml:",chardata"`
+				} `xml:"failure"`
+			} `xml:"testcase"`
+		}
+		if err := xml.Unmarshal(data, &suite); err != nil {
+			t.Fatalf("Failed to decode JUnit report: %v\n%s", err, data)
+		}
+		if suite.Tests != 2 || suite.Failures != 1 || suite.TestCases[0].Failure == nil || suite.TestCases[0].Failure.Text != "round 1: status 502" {
+			t.Fatalf("Expected api to fail, got %s", data)
+		}
+	})
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"gitlab.com/tozd/go/errors"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+//go:embed configuration-schema.json
+var configSchemaJSON []byte
+
+var compileConfigSchema = sync.OnceValues(func() (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(configSchemaJSON))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse embedded config schema")
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat()
+	if err := compiler.AddResource("configuration-schema.json", doc); err != nil {
+		return nil, errors.Wrap(err, "failed to add embedded config schema")
+	}
+	return compiler.Compile("configuration-schema.json")
+})
+
+// SchemaViolation is a single violation of the config schema
+type SchemaViolation struct {
+	Field   string // JSON pointer to the offending field, e.g. /smtp/smtpPort
+	Line    int    // 1-based, 0 if unknown
+	Column  int    // 1-based, 0 if unknown
+	Message string
+}
+
+func (v SchemaViolation) String() string {
+	field := v.Field
+	if field == "" {
+		field = "/"
+	}
+	if v.Line > 0 {
+		return fmt.Sprintf("line %d, column %d: %s: %s", v.Line, v.Column, field, v.Message)
+	}
+	return fmt.Sprintf("%s: %s", field, v.Message)
+}
+
+// SchemaError lists all violations of the config schema
+type SchemaError struct {
+	Violations []SchemaViolation
+}
+
+func (e *SchemaError) Error() string {
+	lines := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		lines[i] = v.String()
+	}
+	return "config does not match schema:\n  " + strings.Join(lines, "\n  ")
+}
+
+// validateConfigSchema validates the JSON encoded config against the embedded schema.
+// If locate is given, violations are reported with the line and column of the
+// offending field in the config file.
+func validateConfigSchema(data []byte, locate configLocator) error {
+	schema, err := compileConfigSchema()
+	if err != nil {
+		return err
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse config")
+	}
+
+	err = schema.Validate(instance)
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+
+	printer := message.NewPrinter(language.English)
+	var violations []SchemaViolation
+	var collect func(e *jsonschema.ValidationError)
+	collect = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, cause := range e.Causes {
+				collect(cause)
+			}
+			return
+		}
+
+		// Point at the offending key instead of the object containing it
+		if additional, ok := e.ErrorKind.(*kind.AdditionalProperties); ok {
+			for _, property := range additional.Properties {
+				path := append(slices.Clone(e.InstanceLocation), property)
+				violations = append(violations, newSchemaViolation(locate, path, "unknown field"))
+			}
+			return
+		}
+		violations = append(violations, newSchemaViolation(locate, e.InstanceLocation, e.ErrorKind.LocalizedString(printer)))
+	}
+	collect(validationErr)
+
+	slices.SortStableFunc(violations, func(a, b SchemaViolation) int { return a.Line - b.Line })
+	return &SchemaError{Violations: violations}
+}
+
+func newSchemaViolation(locate configLocator, path []string, msg string) SchemaViolation {
+	v := SchemaViolation{Field: jsonPointer(path), Message: msg}
+	if locate != nil {
+		if line, column, ok := locate(path); ok {
+			v.Line, v.Column = line, column
+		}
+	}
+	return v
+}
+
+func jsonPointer(path []string) string {
+	var sb strings.Builder
+	for _, token := range path {
+		sb.WriteByte('/')
+		sb.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+	return sb.String()
+}
+
+// locateJSONPath returns the byte offset of the value at path in data. For object
+// members the offset of the key is returned.
+func locateJSONPath(data []byte, path []string) (int, bool) {
+	if len(path) == 0 {
+		return skipJSONSeparators(data, 0), true
+	}
+
+	// frame is an object or array being decoded, segment is the key or index of
+	// the member currently being read
+	type frame struct {
+		object    bool
+		expectKey bool
+		segment   string
+		index     int
+	}
+	var stack []*frame
+
+	// isPath reports whether segment within the innermost container is at path
+	isPath := func(segment string) bool {
+		if len(stack) != len(path) || path[len(path)-1] != segment {
+			return false
+		}
+		for i, f := range stack[:len(stack)-1] {
+			if f.segment != path[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		offset := skipJSONSeparators(data, int(dec.InputOffset()))
+		token, err := dec.Token()
+		if err != nil {
+			return 0, false
+		}
+
+		var top *frame
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			if len(stack) > 0 && stack[len(stack)-1].object {
+				stack[len(stack)-1].expectKey = true
+			}
+			continue
+		}
+
+		if top != nil && top.object && top.expectKey {
+			top.segment = token.(string)
+			top.expectKey = false
+			if isPath(top.segment) {
+				return offset, true
+			}
+			continue
+		}
+
+		// token starts a value
+		if top != nil && !top.object {
+			top.segment = strconv.Itoa(top.index)
+			top.index++
+			if isPath(top.segment) {
+				return offset, true
+			}
+		}
+
+		switch token {
+		case json.Delim('{'):
+			stack = append(stack, &frame{object: true, expectKey: true})
+		case json.Delim('['):
+			stack = append(stack, &frame{})
+		default:
+			if top != nil && top.object {
+				top.expectKey = true
+			}
+		}
+	}
+}
+
+func skipJSONSeparators(data []byte, offset int) int {
+	for offset < len(data) && strings.IndexByte(" \t\r\n,:", data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
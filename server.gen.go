@@ -8,18 +8,94 @@ package main
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
 	"time"
 
+	"gopkg.in/yaml.v2"
+
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/oapi-codegen/runtime"
 )
 
+const (
+	ApiKeyScopes     = "apiKey.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for EventType.
+const (
+	EventCheck    EventType = "check"
+	EventIncident EventType = "incident"
+	EventState    EventType = "state"
+)
+
+// Defines values for FailedNotificationKind.
+const (
+	FailedNotificationKindAlert   FailedNotificationKind = "alert"
+	FailedNotificationKindResolve FailedNotificationKind = "resolve"
+)
+
+// Defines values for NotificationTestResultKind.
+const (
+	NotificationTestResultKindAlert   NotificationTestResultKind = "alert"
+	NotificationTestResultKindResolve NotificationTestResultKind = "resolve"
+)
+
+// Defines values for TargetSource.
+const (
+	SourceAPI    TargetSource = "api"
+	SourceConfig TargetSource = "config"
+)
+
+// Defines values for GetStatusParamsSort.
+const (
+	SortID             GetStatusParamsSort = "id"
+	SortIDDesc         GetStatusParamsSort = "-id"
+	SortLastChange     GetStatusParamsSort = "lastChange"
+	SortLastChangeDesc GetStatusParamsSort = "-lastChange"
+	SortLatency        GetStatusParamsSort = "latency"
+	SortLatencyDesc    GetStatusParamsSort = "-latency"
+)
+
+// Defines values for BulkImportTargetsParamsMode.
+const (
+	Replace BulkImportTargetsParamsMode = "replace"
+	Upsert  BulkImportTargetsParamsMode = "upsert"
+)
+
+// Defines values for ExportTargetsParamsFormat.
+const (
+	Csv  ExportTargetsParamsFormat = "csv"
+	Json ExportTargetsParamsFormat = "json"
+	Yaml ExportTargetsParamsFormat = "yaml"
+)
+
+// BulkImportResult defines model for BulkImportResult.
+type BulkImportResult struct {
+	// Created IDs of the targets that were added
+	Created []string `json:"created"`
+
+	// Deleted IDs of the targets that were removed, only in replace mode
+	Deleted []string `json:"deleted"`
+
+	// DryRun Whether the changes were only computed and not applied
+	DryRun bool `json:"dryRun"`
+
+	// Unchanged IDs of the targets that already matched the import
+	Unchanged []string `json:"unchanged"`
+
+	// Updated IDs of the targets that were replaced
+	Updated []string `json:"updated"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	// Code Error code static for the error type
@@ -29,8 +105,51 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// EventType check: a check finished, state: a target turned healthy or unhealthy,
+// incident: an alert was sent or resolved
+type EventType string
+
+// FailedNotification defines model for FailedNotification.
+type FailedNotification struct {
+	// Attempts Number of delivery attempts
+	Attempts int `json:"attempts"`
+
+	// Channel Name of the notification channel, e.g. email or telegram
+	Channel string `json:"channel"`
+
+	// CreatedAt When the notification was queued
+	CreatedAt time.Time `json:"created_at"`
+
+	// FailedAt When the notification was given up on
+	FailedAt time.Time `json:"failed_at"`
+
+	// Id Notification identifier
+	Id string `json:"id"`
+
+	// Kind Whether the notification was an alert or a resolution notice
+	Kind FailedNotificationKind `json:"kind"`
+
+	// LastError Error returned by the last delivery attempt
+	LastError *string `json:"last_error,omitempty"`
+
+	// TargetId Identifier of the target the notification was about
+	TargetId string `json:"target_id"`
+
+	// Url The monitored URL
+	Url string `json:"url"`
+}
+
+// FailedNotificationKind Whether the notification was an alert or a resolution notice
+type FailedNotificationKind string
+
 // HealthCheckResult defines model for HealthCheckResult.
 type HealthCheckResult struct {
+	// Alerted Whether an alert was sent and the target hasn't recovered since
+	Alerted *bool `json:"alerted,omitempty"`
+
+	// ConsecutiveFailures Number of failed scheduled checks in a row
+	ConsecutiveFailures *int `json:"consecutive_failures,omitempty"`
+
 	// DurationSeconds Duration of the health check in seconds
 	DurationSeconds float32 `json:"duration_seconds"`
 
@@ -43,6 +162,22 @@ type HealthCheckResult struct {
 	// Id Target identifier
 	Id string `json:"id"`
 
+	// Labels Labels to select targets by. Keys start with a letter or digit and, like values, may only contain
+	// letters, digits, '.', '_', '-' and '/'.
+	Labels *Labels `json:"labels,omitempty"`
+
+	// LastChange When the target last turned healthy or unhealthy
+	LastChange *time.Time `json:"last_change,omitempty"`
+
+	// Namespace Namespace the target belongs to, missing for the default namespace
+	Namespace *string `json:"namespace,omitempty"`
+
+	// Pending Whether the target wasn't checked yet, its status, timestamp and duration are empty then
+	Pending *bool `json:"pending,omitempty"`
+
+	// Source Where the target was registered, targets declared in the config file can't be changed through the API
+	Source *TargetSource `json:"source,omitempty"`
+
 	// Status HTTP status code from the health check
 	Status int `json:"status"`
 
@@ -53,18 +188,197 @@ type HealthCheckResult struct {
 	Url string `json:"url"`
 }
 
+// HistoryEntry defines model for HistoryEntry.
+type HistoryEntry struct {
+	DurationSeconds float64 `json:"duration_seconds"`
+	Error           *string `json:"error,omitempty"`
+	Healthy         bool    `json:"healthy"`
+
+	// Status HTTP status code, 0 if the request failed
+	Status    int       `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Incident defines model for Incident.
+type Incident struct {
+	// Error Why the target was unhealthy when the incident was opened
+	Error     *string   `json:"error,omitempty"`
+	Id        int64     `json:"id"`
+	Namespace *string   `json:"namespace,omitempty"`
+	OpenedAt  time.Time `json:"opened_at"`
+
+	// Removed Whether the incident was resolved because its target was removed rather than recovering
+	Removed bool `json:"removed"`
+
+	// ResolvedAt Missing while the incident is open
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+
+	// Silenced Whether the alert was suppressed by a silence
+	Silenced bool   `json:"silenced"`
+	TargetId string `json:"target_id"`
+	Url      string `json:"url"`
+}
+
+// Labels Labels to select targets by. Keys start with a letter or digit and, like values, may only contain
+// letters, digits, '.', '_', '-' and '/'.
+type Labels map[string]string
+
+// NewSilence defines model for NewSilence.
+type NewSilence struct {
+	// Comment Why the targets are silenced
+	Comment string    `json:"comment"`
+	EndsAt  time.Time `json:"endsAt"`
+
+	// Label Label selectors the silenced targets must match, see the label query parameter
+	Label *[]string `json:"label,omitempty"`
+
+	// StartsAt Start of the silence, now if omitted
+	StartsAt *time.Time `json:"startsAt,omitempty"`
+
+	// Targets IDs of the silenced targets, all targets matching label if omitted
+	Targets *[]string `json:"targets,omitempty"`
+}
+
+// NewStatusPageUpdate defines model for NewStatusPageUpdate.
+type NewStatusPageUpdate struct {
+	// Message Shown publicly, e.g. what is affected and when a fix is expected
+	Message string `json:"message"`
+}
+
+// NotificationTestResult defines model for NotificationTestResult.
+type NotificationTestResult struct {
+	// Channel Name of the notification channel
+	Channel string `json:"channel"`
+
+	// Error Error returned by the channel if the delivery failed
+	Error *string `json:"error,omitempty"`
+
+	// Kind Whether the synthetic alert or resolution notice was sent
+	Kind NotificationTestResultKind `json:"kind"`
+
+	// Success Whether the notification was delivered
+	Success bool `json:"success"`
+}
+
+// NotificationTestResultKind Whether the synthetic alert or resolution notice was sent
+type NotificationTestResultKind string
+
+// Silence defines model for Silence.
+type Silence struct {
+	// Comment Why the targets are silenced
+	Comment string `json:"comment"`
+
+	// CreatedBy Client that created the silence, empty without authentication
+	CreatedBy string    `json:"createdBy"`
+	EndsAt    time.Time `json:"endsAt"`
+	Id        int64     `json:"id"`
+
+	// Label Label selectors the silenced targets must match, see the label query parameter
+	Label     *[]string `json:"label,omitempty"`
+	Namespace *string   `json:"namespace,omitempty"`
+
+	// StartsAt Start of the silence, now if omitted
+	StartsAt time.Time `json:"startsAt"`
+
+	// Targets IDs of the silenced targets, all targets matching label if omitted
+	Targets *[]string `json:"targets,omitempty"`
+}
+
+// StatusPageIncident defines model for StatusPageIncident.
+type StatusPageIncident struct {
+	Component string `json:"component"`
+	Id        int64  `json:"id"`
+
+	// ResolvedAt Missing while one of the component's targets is still failing
+	ResolvedAt *time.Time         `json:"resolvedAt,omitempty"`
+	StartedAt  time.Time          `json:"startedAt"`
+	Title      string             `json:"title"`
+	Updates    []StatusPageUpdate `json:"updates"`
+}
+
+// StatusPageUpdate defines model for StatusPageUpdate.
+type StatusPageUpdate struct {
+	At time.Time `json:"at"`
+
+	// Author Client that posted the update, not shown on the status page
+	Author *string `json:"author,omitempty"`
+
+	// Message Shown publicly, e.g. what is affected and when a fix is expected
+	Message string `json:"message"`
+}
+
 // Target defines model for Target.
 type Target struct {
-	// Id Unique identifier for the target
+	// Id Identifier of the target, unique within its namespace
 	Id string `json:"id"`
 
+	// Labels Labels to select targets by. Keys start with a letter or digit and, like values, may only contain
+	// letters, digits, '.', '_', '-' and '/'.
+	Labels *Labels `json:"labels,omitempty"`
+
+	// Namespace Namespace the target belongs to, missing for the default namespace
+	Namespace *string `json:"namespace,omitempty"`
+
+	// Source Where the target was registered, targets declared in the config file can't be changed through the API
+	Source *TargetSource `json:"source,omitempty"`
+
+	// TimeoutInSec Timeout of a single check in seconds, overrides the configured checkTimeoutInSec
+	TimeoutInSec *int `json:"timeoutInSec,omitempty"`
+
+	// Url The URL to be monitored
+	Url string `json:"url"`
+}
+
+// TargetPatch Fields to change, omitted fields are left as they are
+type TargetPatch struct {
+	// Labels Labels to select targets by. Keys start with a letter or digit and, like values, may only contain
+	// letters, digits, '.', '_', '-' and '/'.
+	Labels *Labels `json:"labels,omitempty"`
+
+	// TimeoutInSec Timeout of a single check in seconds, 0 resets it to the configured checkTimeoutInSec
+	TimeoutInSec *int `json:"timeoutInSec,omitempty"`
+
+	// Url The URL to be monitored
+	Url *string `json:"url,omitempty"`
+}
+
+// TargetSource Where the target was registered, targets declared in the config file can't be changed through the API
+type TargetSource string
+
+// TargetSpec defines model for TargetSpec.
+type TargetSpec struct {
+	// Labels Labels to select targets by. Keys start with a letter or digit and, like values, may only contain
+	// letters, digits, '.', '_', '-' and '/'.
+	Labels *Labels `json:"labels,omitempty"`
+
+	// TimeoutInSec Timeout of a single check in seconds, overrides the configured checkTimeoutInSec
+	TimeoutInSec *int `json:"timeoutInSec,omitempty"`
+
 	// Url The URL to be monitored
 	Url string `json:"url"`
 }
 
+// Cursor defines model for Cursor.
+type Cursor = string
+
+// IdPrefix defines model for IdPrefix.
+type IdPrefix = string
+
+// LabelSelector defines model for LabelSelector.
+type LabelSelector = []string
+
+// Limit defines model for Limit.
+type Limit = int
+
 // BadRequest defines model for BadRequest.
 type BadRequest = Error
 
+// Conflict defines model for Conflict.
+type Conflict = Error
+
+// Forbidden defines model for Forbidden.
+type Forbidden = Error
+
 // InternalServerError defines model for InternalServerError.
 type InternalServerError = Error
 
@@ -74,89 +388,4945 @@ type NotAllowed = Error
 // NotFound defines model for NotFound.
 type NotFound = Error
 
-// RegisterTargetJSONRequestBody defines body for RegisterTarget for application/json ContentType.
-type RegisterTargetJSONRequestBody = Target
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = Error
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Get the health status of the API
-	// (GET /health)
-	GetHealth(w http.ResponseWriter, r *http.Request)
-	// Register a new URL for health checking
-	// (POST /register)
-	RegisterTarget(w http.ResponseWriter, r *http.Request)
-	// Get health check status for all registered targets
-	// (GET /status)
-	GetStatus(w http.ResponseWriter, r *http.Request)
-	// Unregister a URL from health checking
-	// (DELETE /unregister/{id})
-	UnregisterTarget(w http.ResponseWriter, r *http.Request, id string)
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = Error
+
+// UnsupportedMediaType defines model for UnsupportedMediaType.
+type UnsupportedMediaType = Error
+
+// StreamEventsParams defines parameters for StreamEvents.
+type StreamEventsParams struct {
+	// Target Only send events of these target IDs
+	Target *[]string `form:"target,omitempty" json:"target,omitempty"`
+
+	// Type Only send these kinds of events, all kinds if omitted
+	Type *[]EventType `form:"type,omitempty" json:"type,omitempty"`
+
+	// Label Only return targets matching all selectors. A selector is key=value, key!=value, key to require
+	// a label or !key to exclude it. Several selectors can be given comma separated or by repeating the parameter.
+	Label *LabelSelector `form:"label,omitempty" json:"label,omitempty"`
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// ListIncidentsParams defines parameters for ListIncidents.
+type ListIncidentsParams struct {
+	// Target Only return incidents of this target ID
+	Target *string `form:"target,omitempty" json:"target,omitempty"`
+
+	// Open Only return open or only resolved incidents
+	Open *bool `form:"open,omitempty" json:"open,omitempty"`
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// TestNotificationsParams defines parameters for TestNotifications.
+type TestNotificationsParams struct {
+	// Channel Only test the given channel, e.g. email or telegram
+	Channel *string `form:"channel,omitempty" json:"channel,omitempty"`
+}
 
-// GetHealth operation middleware
-func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+// GetStatusParams defines parameters for GetStatus.
+type GetStatusParams struct {
+	// Fresh Check all targets now instead of returning the latest results
+	Fresh *bool `form:"fresh,omitempty" json:"fresh,omitempty"`
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetHealth(w, r)
-	}))
+	// Healthy Only return healthy or only unhealthy targets, pending targets are left out
+	Healthy *bool `form:"healthy,omitempty" json:"healthy,omitempty"`
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// Label Only return targets matching all selectors. A selector is key=value, key!=value, key to require
+	// a label or !key to exclude it. Several selectors can be given comma separated or by repeating the parameter.
+	Label *LabelSelector `form:"label,omitempty" json:"label,omitempty"`
 
-	handler.ServeHTTP(w, r)
+	// IdPrefix Only return targets whose ID starts with the prefix
+	IdPrefix *IdPrefix `form:"idPrefix,omitempty" json:"idPrefix,omitempty"`
+
+	// Sort Field to sort by, prefixed with - for descending order. Ties are ordered by ID.
+	Sort *GetStatusParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Limit Maximum number of items per page, all items are returned if omitted
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor The X-Next-Cursor header of the previous page, the other query parameters must stay the same
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
-// RegisterTarget operation middleware
-func (siw *ServerInterfaceWrapper) RegisterTarget(w http.ResponseWriter, r *http.Request) {
+// GetStatusParamsSort defines parameters for GetStatus.
+type GetStatusParamsSort string
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RegisterTarget(w, r)
-	}))
+// ListTargetsParams defines parameters for ListTargets.
+type ListTargetsParams struct {
+	// Label Only return targets matching all selectors. A selector is key=value, key!=value, key to require
+	// a label or !key to exclude it. Several selectors can be given comma separated or by repeating the parameter.
+	Label *LabelSelector `form:"label,omitempty" json:"label,omitempty"`
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	// IdPrefix Only return targets whose ID starts with the prefix
+	IdPrefix *IdPrefix `form:"idPrefix,omitempty" json:"idPrefix,omitempty"`
+
+	// Limit Maximum number of items per page, all items are returned if omitted
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor The X-Next-Cursor header of the previous page, the other query parameters must stay the same
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// BulkImportTargetsJSONBody defines parameters for BulkImportTargets.
+type BulkImportTargetsJSONBody = []Target
+
+// BulkImportTargetsParams defines parameters for BulkImportTargets.
+type BulkImportTargetsParams struct {
+	// Mode upsert adds new and replaces existing targets, replace additionally removes
+	// the targets registered through the API that are missing from the import
+	Mode *BulkImportTargetsParamsMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// DryRun Only report the changes without applying them
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+}
+
+// BulkImportTargetsParamsMode defines parameters for BulkImportTargets.
+type BulkImportTargetsParamsMode string
+
+// ExportTargetsParams defines parameters for ExportTargets.
+type ExportTargetsParams struct {
+	Format *ExportTargetsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportTargetsParamsFormat defines parameters for ExportTargets.
+type ExportTargetsParamsFormat string
+
+// RegisterTargetJSONRequestBody defines body for RegisterTarget for application/json ContentType.
+type RegisterTargetJSONRequestBody = Target
+
+// CreateSilenceJSONRequestBody defines body for CreateSilence for application/json ContentType.
+type CreateSilenceJSONRequestBody = NewSilence
+
+// AddStatusPageUpdateJSONRequestBody defines body for AddStatusPageUpdate for application/json ContentType.
+type AddStatusPageUpdateJSONRequestBody = NewStatusPageUpdate
+
+// CreateTargetJSONRequestBody defines body for CreateTarget for application/json ContentType.
+type CreateTargetJSONRequestBody = Target
+
+// UpdateTargetJSONRequestBody defines body for UpdateTarget for application/json ContentType.
+type UpdateTargetJSONRequestBody = TargetPatch
+
+// ReplaceTargetJSONRequestBody defines body for ReplaceTarget for application/json ContentType.
+type ReplaceTargetJSONRequestBody = TargetSpec
+
+// BulkImportTargetsJSONRequestBody defines body for BulkImportTargets for application/json ContentType.
+type BulkImportTargetsJSONRequestBody = BulkImportTargetsJSONBody
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
 	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
 
-	handler.ServeHTTP(w, r)
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
 }
 
-// GetStatus operation middleware
-func (siw *ServerInterfaceWrapper) GetStatus(w http.ResponseWriter, r *http.Request) {
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetStatus(w, r)
-	}))
+// The interface specification for the client above.
+type ClientInterface interface {
+	// StreamEvents request
+	StreamEvents(ctx context.Context, params *StreamEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	// GetHealth request
+	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListIncidents request
+	ListIncidents(ctx context.Context, params *ListIncidentsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFailedNotifications request
+	GetFailedNotifications(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TestNotifications request
+	TestNotifications(ctx context.Context, params *TestNotificationsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RegisterTargetWithBody request with any body
+	RegisterTargetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RegisterTarget(ctx context.Context, body RegisterTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListSilences request
+	ListSilences(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateSilenceWithBody request with any body
+	CreateSilenceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateSilence(ctx context.Context, body CreateSilenceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteSilence request
+	DeleteSilence(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetStatus request
+	GetStatus(ctx context.Context, params *GetStatusParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListStatusPageIncidents request
+	ListStatusPageIncidents(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddStatusPageUpdateWithBody request with any body
+	AddStatusPageUpdateWithBody(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddStatusPageUpdate(ctx context.Context, id int64, body AddStatusPageUpdateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListTargets request
+	ListTargets(ctx context.Context, params *ListTargetsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateTargetWithBody request with any body
+	CreateTargetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateTarget(ctx context.Context, body CreateTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteTarget request
+	DeleteTarget(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTarget request
+	GetTarget(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateTargetWithBody request with any body
+	UpdateTargetWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateTarget(ctx context.Context, id string, body UpdateTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReplaceTargetWithBody request with any body
+	ReplaceTargetWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ReplaceTarget(ctx context.Context, id string, body ReplaceTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CheckTarget request
+	CheckTarget(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTargetHistory request
+	GetTargetHistory(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// BulkImportTargetsWithBody request with any body
+	BulkImportTargetsWithBody(ctx context.Context, params *BulkImportTargetsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	BulkImportTargets(ctx context.Context, params *BulkImportTargetsParams, body BulkImportTargetsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportTargets request
+	ExportTargets(ctx context.Context, params *ExportTargetsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UnregisterTarget request
+	UnregisterTarget(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) StreamEvents(ctx context.Context, params *StreamEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamEventsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	handler.ServeHTTP(w, r)
+func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// UnregisterTarget operation middleware
-func (siw *ServerInterfaceWrapper) UnregisterTarget(w http.ResponseWriter, r *http.Request) {
+func (c *Client) ListIncidents(ctx context.Context, params *ListIncidentsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListIncidentsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	var err error
+func (c *Client) GetFailedNotifications(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFailedNotificationsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	// ------------- Path parameter "id" -------------
-	var id string
+func (c *Client) TestNotifications(ctx context.Context, params *TestNotificationsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTestNotificationsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+func (c *Client) RegisterTargetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterTargetRequestWithBody(c.Server, contentType, body)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterTarget(ctx context.Context, body RegisterTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterTargetRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListSilences(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListSilencesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSilenceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSilenceRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSilence(ctx context.Context, body CreateSilenceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSilenceRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteSilence(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteSilenceRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetStatus(ctx context.Context, params *GetStatusParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStatusRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListStatusPageIncidents(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListStatusPageIncidentsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddStatusPageUpdateWithBody(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddStatusPageUpdateRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddStatusPageUpdate(ctx context.Context, id int64, body AddStatusPageUpdateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddStatusPageUpdateRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListTargets(ctx context.Context, params *ListTargetsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListTargetsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateTargetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateTargetRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateTarget(ctx context.Context, body CreateTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateTargetRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteTarget(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteTargetRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTarget(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTargetRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateTargetWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTargetRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateTarget(ctx context.Context, id string, body UpdateTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTargetRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReplaceTargetWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReplaceTargetRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReplaceTarget(ctx context.Context, id string, body ReplaceTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReplaceTargetRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CheckTarget(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCheckTargetRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTargetHistory(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTargetHistoryRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BulkImportTargetsWithBody(ctx context.Context, params *BulkImportTargetsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBulkImportTargetsRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BulkImportTargets(ctx context.Context, params *BulkImportTargetsParams, body BulkImportTargetsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBulkImportTargetsRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportTargets(ctx context.Context, params *ExportTargetsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportTargetsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UnregisterTarget(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnregisterTargetRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewStreamEventsRequest generates requests for StreamEvents
+func NewStreamEventsRequest(server string, params *StreamEventsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/events")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Target != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "target", runtime.ParamLocationQuery, *params.Target); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Type != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Label != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "label", runtime.ParamLocationQuery, *params.Label); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHealthRequest generates requests for GetHealth
+func NewGetHealthRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/health")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListIncidentsRequest generates requests for ListIncidents
+func NewListIncidentsRequest(server string, params *ListIncidentsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/incidents")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Target != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "target", runtime.ParamLocationQuery, *params.Target); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Open != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "open", runtime.ParamLocationQuery, *params.Open); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetFailedNotificationsRequest generates requests for GetFailedNotifications
+func NewGetFailedNotificationsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/notifications/failed")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTestNotificationsRequest generates requests for TestNotifications
+func NewTestNotificationsRequest(server string, params *TestNotificationsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/notifications/test")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Channel != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "channel", runtime.ParamLocationQuery, *params.Channel); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRegisterTargetRequest calls the generic RegisterTarget builder with application/json body
+func NewRegisterTargetRequest(server string, body RegisterTargetJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRegisterTargetRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewRegisterTargetRequestWithBody generates requests for RegisterTarget with any type of body
+func NewRegisterTargetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/register")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListSilencesRequest generates requests for ListSilences
+func NewListSilencesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/silences")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateSilenceRequest calls the generic CreateSilence builder with application/json body
+func NewCreateSilenceRequest(server string, body CreateSilenceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateSilenceRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateSilenceRequestWithBody generates requests for CreateSilence with any type of body
+func NewCreateSilenceRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/silences")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteSilenceRequest generates requests for DeleteSilence
+func NewDeleteSilenceRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/silences/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetStatusRequest generates requests for GetStatus
+func NewGetStatusRequest(server string, params *GetStatusParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/status")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Fresh != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "fresh", runtime.ParamLocationQuery, *params.Fresh); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Healthy != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "healthy", runtime.ParamLocationQuery, *params.Healthy); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Label != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "label", runtime.ParamLocationQuery, *params.Label); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.IdPrefix != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "idPrefix", runtime.ParamLocationQuery, *params.IdPrefix); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListStatusPageIncidentsRequest generates requests for ListStatusPageIncidents
+func NewListStatusPageIncidentsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/status-page/incidents")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddStatusPageUpdateRequest calls the generic AddStatusPageUpdate builder with application/json body
+func NewAddStatusPageUpdateRequest(server string, id int64, body AddStatusPageUpdateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddStatusPageUpdateRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewAddStatusPageUpdateRequestWithBody generates requests for AddStatusPageUpdate with any type of body
+func NewAddStatusPageUpdateRequestWithBody(server string, id int64, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/status-page/incidents/%s/updates", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListTargetsRequest generates requests for ListTargets
+func NewListTargetsRequest(server string, params *ListTargetsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/targets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Label != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "label", runtime.ParamLocationQuery, *params.Label); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.IdPrefix != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "idPrefix", runtime.ParamLocationQuery, *params.IdPrefix); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateTargetRequest calls the generic CreateTarget builder with application/json body
+func NewCreateTargetRequest(server string, body CreateTargetJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateTargetRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateTargetRequestWithBody generates requests for CreateTarget with any type of body
+func NewCreateTargetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/targets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteTargetRequest generates requests for DeleteTarget
+func NewDeleteTargetRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/targets/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTargetRequest generates requests for GetTarget
+func NewGetTargetRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/targets/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateTargetRequest calls the generic UpdateTarget builder with application/json body
+func NewUpdateTargetRequest(server string, id string, body UpdateTargetJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateTargetRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUpdateTargetRequestWithBody generates requests for UpdateTarget with any type of body
+func NewUpdateTargetRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/targets/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewReplaceTargetRequest calls the generic ReplaceTarget builder with application/json body
+func NewReplaceTargetRequest(server string, id string, body ReplaceTargetJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewReplaceTargetRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewReplaceTargetRequestWithBody generates requests for ReplaceTarget with any type of body
+func NewReplaceTargetRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/targets/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCheckTargetRequest generates requests for CheckTarget
+func NewCheckTargetRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/targets/%s/check", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTargetHistoryRequest generates requests for GetTargetHistory
+func NewGetTargetHistoryRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/targets/%s/history", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewBulkImportTargetsRequest calls the generic BulkImportTargets builder with application/json body
+func NewBulkImportTargetsRequest(server string, params *BulkImportTargetsParams, body BulkImportTargetsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewBulkImportTargetsRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewBulkImportTargetsRequestWithBody generates requests for BulkImportTargets with any type of body
+func NewBulkImportTargetsRequestWithBody(server string, params *BulkImportTargetsParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/targets:bulk")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Mode != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "mode", runtime.ParamLocationQuery, *params.Mode); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.DryRun != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "dryRun", runtime.ParamLocationQuery, *params.DryRun); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewExportTargetsRequest generates requests for ExportTargets
+func NewExportTargetsRequest(server string, params *ExportTargetsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/targets:export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUnregisterTargetRequest generates requests for UnregisterTarget
+func NewUnregisterTargetRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/unregister/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// StreamEventsWithResponse request
+	StreamEventsWithResponse(ctx context.Context, params *StreamEventsParams, reqEditors ...RequestEditorFn) (*StreamEventsResponse, error)
+
+	// GetHealthWithResponse request
+	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+
+	// ListIncidentsWithResponse request
+	ListIncidentsWithResponse(ctx context.Context, params *ListIncidentsParams, reqEditors ...RequestEditorFn) (*ListIncidentsResponse, error)
+
+	// GetFailedNotificationsWithResponse request
+	GetFailedNotificationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetFailedNotificationsResponse, error)
+
+	// TestNotificationsWithResponse request
+	TestNotificationsWithResponse(ctx context.Context, params *TestNotificationsParams, reqEditors ...RequestEditorFn) (*TestNotificationsResponse, error)
+
+	// RegisterTargetWithBodyWithResponse request with any body
+	RegisterTargetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterTargetResponse, error)
+
+	RegisterTargetWithResponse(ctx context.Context, body RegisterTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterTargetResponse, error)
+
+	// ListSilencesWithResponse request
+	ListSilencesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListSilencesResponse, error)
+
+	// CreateSilenceWithBodyWithResponse request with any body
+	CreateSilenceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSilenceResponse, error)
+
+	CreateSilenceWithResponse(ctx context.Context, body CreateSilenceJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSilenceResponse, error)
+
+	// DeleteSilenceWithResponse request
+	DeleteSilenceWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteSilenceResponse, error)
+
+	// GetStatusWithResponse request
+	GetStatusWithResponse(ctx context.Context, params *GetStatusParams, reqEditors ...RequestEditorFn) (*GetStatusResponse, error)
+
+	// ListStatusPageIncidentsWithResponse request
+	ListStatusPageIncidentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListStatusPageIncidentsResponse, error)
+
+	// AddStatusPageUpdateWithBodyWithResponse request with any body
+	AddStatusPageUpdateWithBodyWithResponse(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddStatusPageUpdateResponse, error)
+
+	AddStatusPageUpdateWithResponse(ctx context.Context, id int64, body AddStatusPageUpdateJSONRequestBody, reqEditors ...RequestEditorFn) (*AddStatusPageUpdateResponse, error)
+
+	// ListTargetsWithResponse request
+	ListTargetsWithResponse(ctx context.Context, params *ListTargetsParams, reqEditors ...RequestEditorFn) (*ListTargetsResponse, error)
+
+	// CreateTargetWithBodyWithResponse request with any body
+	CreateTargetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateTargetResponse, error)
+
+	CreateTargetWithResponse(ctx context.Context, body CreateTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateTargetResponse, error)
+
+	// DeleteTargetWithResponse request
+	DeleteTargetWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteTargetResponse, error)
+
+	// GetTargetWithResponse request
+	GetTargetWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetTargetResponse, error)
+
+	// UpdateTargetWithBodyWithResponse request with any body
+	UpdateTargetWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTargetResponse, error)
+
+	UpdateTargetWithResponse(ctx context.Context, id string, body UpdateTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTargetResponse, error)
+
+	// ReplaceTargetWithBodyWithResponse request with any body
+	ReplaceTargetWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceTargetResponse, error)
+
+	ReplaceTargetWithResponse(ctx context.Context, id string, body ReplaceTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceTargetResponse, error)
+
+	// CheckTargetWithResponse request
+	CheckTargetWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*CheckTargetResponse, error)
+
+	// GetTargetHistoryWithResponse request
+	GetTargetHistoryWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetTargetHistoryResponse, error)
+
+	// BulkImportTargetsWithBodyWithResponse request with any body
+	BulkImportTargetsWithBodyWithResponse(ctx context.Context, params *BulkImportTargetsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BulkImportTargetsResponse, error)
+
+	BulkImportTargetsWithResponse(ctx context.Context, params *BulkImportTargetsParams, body BulkImportTargetsJSONRequestBody, reqEditors ...RequestEditorFn) (*BulkImportTargetsResponse, error)
+
+	// ExportTargetsWithResponse request
+	ExportTargetsWithResponse(ctx context.Context, params *ExportTargetsParams, reqEditors ...RequestEditorFn) (*ExportTargetsResponse, error)
+
+	// UnregisterTargetWithResponse request
+	UnregisterTargetWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*UnregisterTargetResponse, error)
+}
+
+type StreamEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON405      *NotAllowed
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON500      *InternalServerError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListIncidentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Incident
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON405      *NotAllowed
+}
+
+// Status returns HTTPResponse.Status
+func (r ListIncidentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListIncidentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFailedNotificationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]FailedNotification
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON405      *NotAllowed
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFailedNotificationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFailedNotificationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TestNotificationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]NotificationTestResult
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON405      *NotAllowed
+}
+
+// Status returns HTTPResponse.Status
+func (r TestNotificationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TestNotificationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RegisterTargetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON405      *NotAllowed
+	JSON409      *Conflict
+	JSON500      *InternalServerError
+}
+
+// Status returns HTTPResponse.Status
+func (r RegisterTargetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RegisterTargetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListSilencesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Silence
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON405      *NotAllowed
+}
+
+// Status returns HTTPResponse.Status
+func (r ListSilencesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListSilencesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateSilenceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Silence
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON405      *NotAllowed
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateSilenceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateSilenceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteSilenceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON405      *NotAllowed
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteSilenceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteSilenceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]HealthCheckResult
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON405      *NotAllowed
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListStatusPageIncidentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]StatusPageIncident
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON405      *NotAllowed
+}
+
+// Status returns HTTPResponse.Status
+func (r ListStatusPageIncidentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListStatusPageIncidentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddStatusPageUpdateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *StatusPageIncident
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON405      *NotAllowed
+}
+
+// Status returns HTTPResponse.Status
+func (r AddStatusPageUpdateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddStatusPageUpdateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListTargetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Target
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON405      *NotAllowed
+}
+
+// Status returns HTTPResponse.Status
+func (r ListTargetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTargetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateTargetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Target
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON405      *NotAllowed
+	JSON409      *Conflict
+	JSON500      *InternalServerError
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateTargetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateTargetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteTargetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON405      *NotAllowed
+	JSON500      *InternalServerError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteTargetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteTargetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTargetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Target
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON405      *NotAllowed
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTargetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTargetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateTargetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Target
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON405      *NotAllowed
+	JSON500      *InternalServerError
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateTargetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateTargetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReplaceTargetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Target
+	JSON201      *Target
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON405      *NotAllowed
+	JSON500      *InternalServerError
+}
+
+// Status returns HTTPResponse.Status
+func (r ReplaceTargetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReplaceTargetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CheckTargetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthCheckResult
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON405      *NotAllowed
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r CheckTargetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CheckTargetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTargetHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]HistoryEntry
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON405      *NotAllowed
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTargetHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTargetHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type BulkImportTargetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BulkImportResult
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON405      *NotAllowed
+	JSON415      *UnsupportedMediaType
+	JSON500      *InternalServerError
+}
+
+// Status returns HTTPResponse.Status
+func (r BulkImportTargetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r BulkImportTargetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportTargetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Target
+	YAML200      *[]Target
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON405      *NotAllowed
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportTargetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportTargetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UnregisterTargetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON405      *NotAllowed
+	JSON500      *InternalServerError
+}
+
+// Status returns HTTPResponse.Status
+func (r UnregisterTargetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UnregisterTargetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// StreamEventsWithResponse request returning *StreamEventsResponse
+func (c *ClientWithResponses) StreamEventsWithResponse(ctx context.Context, params *StreamEventsParams, reqEditors ...RequestEditorFn) (*StreamEventsResponse, error) {
+	rsp, err := c.StreamEvents(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamEventsResponse(rsp)
+}
+
+// GetHealthWithResponse request returning *GetHealthResponse
+func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
+	rsp, err := c.GetHealth(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthResponse(rsp)
+}
+
+// ListIncidentsWithResponse request returning *ListIncidentsResponse
+func (c *ClientWithResponses) ListIncidentsWithResponse(ctx context.Context, params *ListIncidentsParams, reqEditors ...RequestEditorFn) (*ListIncidentsResponse, error) {
+	rsp, err := c.ListIncidents(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListIncidentsResponse(rsp)
+}
+
+// GetFailedNotificationsWithResponse request returning *GetFailedNotificationsResponse
+func (c *ClientWithResponses) GetFailedNotificationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetFailedNotificationsResponse, error) {
+	rsp, err := c.GetFailedNotifications(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFailedNotificationsResponse(rsp)
+}
+
+// TestNotificationsWithResponse request returning *TestNotificationsResponse
+func (c *ClientWithResponses) TestNotificationsWithResponse(ctx context.Context, params *TestNotificationsParams, reqEditors ...RequestEditorFn) (*TestNotificationsResponse, error) {
+	rsp, err := c.TestNotifications(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTestNotificationsResponse(rsp)
+}
+
+// RegisterTargetWithBodyWithResponse request with arbitrary body returning *RegisterTargetResponse
+func (c *ClientWithResponses) RegisterTargetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterTargetResponse, error) {
+	rsp, err := c.RegisterTargetWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterTargetResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegisterTargetWithResponse(ctx context.Context, body RegisterTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterTargetResponse, error) {
+	rsp, err := c.RegisterTarget(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterTargetResponse(rsp)
+}
+
+// ListSilencesWithResponse request returning *ListSilencesResponse
+func (c *ClientWithResponses) ListSilencesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListSilencesResponse, error) {
+	rsp, err := c.ListSilences(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListSilencesResponse(rsp)
+}
+
+// CreateSilenceWithBodyWithResponse request with arbitrary body returning *CreateSilenceResponse
+func (c *ClientWithResponses) CreateSilenceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSilenceResponse, error) {
+	rsp, err := c.CreateSilenceWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSilenceResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateSilenceWithResponse(ctx context.Context, body CreateSilenceJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSilenceResponse, error) {
+	rsp, err := c.CreateSilence(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSilenceResponse(rsp)
+}
+
+// DeleteSilenceWithResponse request returning *DeleteSilenceResponse
+func (c *ClientWithResponses) DeleteSilenceWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteSilenceResponse, error) {
+	rsp, err := c.DeleteSilence(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteSilenceResponse(rsp)
+}
+
+// GetStatusWithResponse request returning *GetStatusResponse
+func (c *ClientWithResponses) GetStatusWithResponse(ctx context.Context, params *GetStatusParams, reqEditors ...RequestEditorFn) (*GetStatusResponse, error) {
+	rsp, err := c.GetStatus(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStatusResponse(rsp)
+}
+
+// ListStatusPageIncidentsWithResponse request returning *ListStatusPageIncidentsResponse
+func (c *ClientWithResponses) ListStatusPageIncidentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListStatusPageIncidentsResponse, error) {
+	rsp, err := c.ListStatusPageIncidents(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListStatusPageIncidentsResponse(rsp)
+}
+
+// AddStatusPageUpdateWithBodyWithResponse request with arbitrary body returning *AddStatusPageUpdateResponse
+func (c *ClientWithResponses) AddStatusPageUpdateWithBodyWithResponse(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddStatusPageUpdateResponse, error) {
+	rsp, err := c.AddStatusPageUpdateWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddStatusPageUpdateResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddStatusPageUpdateWithResponse(ctx context.Context, id int64, body AddStatusPageUpdateJSONRequestBody, reqEditors ...RequestEditorFn) (*AddStatusPageUpdateResponse, error) {
+	rsp, err := c.AddStatusPageUpdate(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddStatusPageUpdateResponse(rsp)
+}
+
+// ListTargetsWithResponse request returning *ListTargetsResponse
+func (c *ClientWithResponses) ListTargetsWithResponse(ctx context.Context, params *ListTargetsParams, reqEditors ...RequestEditorFn) (*ListTargetsResponse, error) {
+	rsp, err := c.ListTargets(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListTargetsResponse(rsp)
+}
+
+// CreateTargetWithBodyWithResponse request with arbitrary body returning *CreateTargetResponse
+func (c *ClientWithResponses) CreateTargetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateTargetResponse, error) {
+	rsp, err := c.CreateTargetWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateTargetResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateTargetWithResponse(ctx context.Context, body CreateTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateTargetResponse, error) {
+	rsp, err := c.CreateTarget(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateTargetResponse(rsp)
+}
+
+// DeleteTargetWithResponse request returning *DeleteTargetResponse
+func (c *ClientWithResponses) DeleteTargetWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteTargetResponse, error) {
+	rsp, err := c.DeleteTarget(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteTargetResponse(rsp)
+}
+
+// GetTargetWithResponse request returning *GetTargetResponse
+func (c *ClientWithResponses) GetTargetWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetTargetResponse, error) {
+	rsp, err := c.GetTarget(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTargetResponse(rsp)
+}
+
+// UpdateTargetWithBodyWithResponse request with arbitrary body returning *UpdateTargetResponse
+func (c *ClientWithResponses) UpdateTargetWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTargetResponse, error) {
+	rsp, err := c.UpdateTargetWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateTargetResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateTargetWithResponse(ctx context.Context, id string, body UpdateTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTargetResponse, error) {
+	rsp, err := c.UpdateTarget(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateTargetResponse(rsp)
+}
+
+// ReplaceTargetWithBodyWithResponse request with arbitrary body returning *ReplaceTargetResponse
+func (c *ClientWithResponses) ReplaceTargetWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceTargetResponse, error) {
+	rsp, err := c.ReplaceTargetWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReplaceTargetResponse(rsp)
+}
+
+func (c *ClientWithResponses) ReplaceTargetWithResponse(ctx context.Context, id string, body ReplaceTargetJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceTargetResponse, error) {
+	rsp, err := c.ReplaceTarget(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReplaceTargetResponse(rsp)
+}
+
+// CheckTargetWithResponse request returning *CheckTargetResponse
+func (c *ClientWithResponses) CheckTargetWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*CheckTargetResponse, error) {
+	rsp, err := c.CheckTarget(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCheckTargetResponse(rsp)
+}
+
+// GetTargetHistoryWithResponse request returning *GetTargetHistoryResponse
+func (c *ClientWithResponses) GetTargetHistoryWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetTargetHistoryResponse, error) {
+	rsp, err := c.GetTargetHistory(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTargetHistoryResponse(rsp)
+}
+
+// BulkImportTargetsWithBodyWithResponse request with arbitrary body returning *BulkImportTargetsResponse
+func (c *ClientWithResponses) BulkImportTargetsWithBodyWithResponse(ctx context.Context, params *BulkImportTargetsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BulkImportTargetsResponse, error) {
+	rsp, err := c.BulkImportTargetsWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBulkImportTargetsResponse(rsp)
+}
+
+func (c *ClientWithResponses) BulkImportTargetsWithResponse(ctx context.Context, params *BulkImportTargetsParams, body BulkImportTargetsJSONRequestBody, reqEditors ...RequestEditorFn) (*BulkImportTargetsResponse, error) {
+	rsp, err := c.BulkImportTargets(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBulkImportTargetsResponse(rsp)
+}
+
+// ExportTargetsWithResponse request returning *ExportTargetsResponse
+func (c *ClientWithResponses) ExportTargetsWithResponse(ctx context.Context, params *ExportTargetsParams, reqEditors ...RequestEditorFn) (*ExportTargetsResponse, error) {
+	rsp, err := c.ExportTargets(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportTargetsResponse(rsp)
+}
+
+// UnregisterTargetWithResponse request returning *UnregisterTargetResponse
+func (c *ClientWithResponses) UnregisterTargetWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*UnregisterTargetResponse, error) {
+	rsp, err := c.UnregisterTarget(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnregisterTargetResponse(rsp)
+}
+
+// ParseStreamEventsResponse parses an HTTP response from a StreamEventsWithResponse call
+func ParseStreamEventsResponse(rsp *http.Response) (*StreamEventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
+func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalServerError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListIncidentsResponse parses an HTTP response from a ListIncidentsWithResponse call
+func ParseListIncidentsResponse(rsp *http.Response) (*ListIncidentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListIncidentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Incident
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetFailedNotificationsResponse parses an HTTP response from a GetFailedNotificationsWithResponse call
+func ParseGetFailedNotificationsResponse(rsp *http.Response) (*GetFailedNotificationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFailedNotificationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []FailedNotification
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTestNotificationsResponse parses an HTTP response from a TestNotificationsWithResponse call
+func ParseTestNotificationsResponse(rsp *http.Response) (*TestNotificationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TestNotificationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []NotificationTestResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRegisterTargetResponse parses an HTTP response from a RegisterTargetWithResponse call
+func ParseRegisterTargetResponse(rsp *http.Response) (*RegisterTargetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RegisterTargetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalServerError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListSilencesResponse parses an HTTP response from a ListSilencesWithResponse call
+func ParseListSilencesResponse(rsp *http.Response) (*ListSilencesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListSilencesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Silence
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateSilenceResponse parses an HTTP response from a CreateSilenceWithResponse call
+func ParseCreateSilenceResponse(rsp *http.Response) (*CreateSilenceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateSilenceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Silence
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteSilenceResponse parses an HTTP response from a DeleteSilenceWithResponse call
+func ParseDeleteSilenceResponse(rsp *http.Response) (*DeleteSilenceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteSilenceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetStatusResponse parses an HTTP response from a GetStatusWithResponse call
+func ParseGetStatusResponse(rsp *http.Response) (*GetStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []HealthCheckResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListStatusPageIncidentsResponse parses an HTTP response from a ListStatusPageIncidentsWithResponse call
+func ParseListStatusPageIncidentsResponse(rsp *http.Response) (*ListStatusPageIncidentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListStatusPageIncidentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []StatusPageIncident
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddStatusPageUpdateResponse parses an HTTP response from a AddStatusPageUpdateWithResponse call
+func ParseAddStatusPageUpdateResponse(rsp *http.Response) (*AddStatusPageUpdateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddStatusPageUpdateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest StatusPageIncident
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListTargetsResponse parses an HTTP response from a ListTargetsWithResponse call
+func ParseListTargetsResponse(rsp *http.Response) (*ListTargetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListTargetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Target
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateTargetResponse parses an HTTP response from a CreateTargetWithResponse call
+func ParseCreateTargetResponse(rsp *http.Response) (*CreateTargetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateTargetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Target
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalServerError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteTargetResponse parses an HTTP response from a DeleteTargetWithResponse call
+func ParseDeleteTargetResponse(rsp *http.Response) (*DeleteTargetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteTargetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalServerError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTargetResponse parses an HTTP response from a GetTargetWithResponse call
+func ParseGetTargetResponse(rsp *http.Response) (*GetTargetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTargetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Target
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateTargetResponse parses an HTTP response from a UpdateTargetWithResponse call
+func ParseUpdateTargetResponse(rsp *http.Response) (*UpdateTargetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateTargetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Target
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalServerError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReplaceTargetResponse parses an HTTP response from a ReplaceTargetWithResponse call
+func ParseReplaceTargetResponse(rsp *http.Response) (*ReplaceTargetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReplaceTargetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Target
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Target
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalServerError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCheckTargetResponse parses an HTTP response from a CheckTargetWithResponse call
+func ParseCheckTargetResponse(rsp *http.Response) (*CheckTargetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CheckTargetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthCheckResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTargetHistoryResponse parses an HTTP response from a GetTargetHistoryWithResponse call
+func ParseGetTargetHistoryResponse(rsp *http.Response) (*GetTargetHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTargetHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []HistoryEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseBulkImportTargetsResponse parses an HTTP response from a BulkImportTargetsWithResponse call
+func ParseBulkImportTargetsResponse(rsp *http.Response) (*BulkImportTargetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BulkImportTargetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BulkImportResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 415:
+		var dest UnsupportedMediaType
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON415 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalServerError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExportTargetsResponse parses an HTTP response from a ExportTargetsWithResponse call
+func ParseExportTargetsResponse(rsp *http.Response) (*ExportTargetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportTargetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Target
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "yaml") && rsp.StatusCode == 200:
+		var dest []Target
+		if err := yaml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.YAML200 = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/csv) unsupported
+
+	}
+
+	return response, nil
+}
+
+// ParseUnregisterTargetResponse parses an HTTP response from a UnregisterTargetWithResponse call
+func ParseUnregisterTargetResponse(rsp *http.Response) (*UnregisterTargetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UnregisterTargetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 405:
+		var dest NotAllowed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON405 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalServerError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Stream health changes as server-sent events
+	// (GET /events)
+	StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams)
+	// Get the health status of the API
+	// (GET /health)
+	GetHealth(w http.ResponseWriter, r *http.Request)
+	// Get the latest incidents, newest first
+	// (GET /incidents)
+	ListIncidents(w http.ResponseWriter, r *http.Request, params ListIncidentsParams)
+	// Get notifications that could not be delivered after all retries
+	// (GET /notifications/failed)
+	GetFailedNotifications(w http.ResponseWriter, r *http.Request)
+	// Send a synthetic DOWN alert and RESOLVED notice through the notification channels
+	// (POST /notifications/test)
+	TestNotifications(w http.ResponseWriter, r *http.Request, params TestNotificationsParams)
+	// Register a new URL for health checking
+	// (POST /register)
+	RegisterTarget(w http.ResponseWriter, r *http.Request)
+	// Get the active and upcoming silences
+	// (GET /silences)
+	ListSilences(w http.ResponseWriter, r *http.Request)
+	// Silence the notifications of targets for a while
+	// (POST /silences)
+	CreateSilence(w http.ResponseWriter, r *http.Request)
+	// End a silence
+	// (DELETE /silences/{id})
+	DeleteSilence(w http.ResponseWriter, r *http.Request, id int64)
+	// Get the latest health check results of all targets
+	// (GET /status)
+	GetStatus(w http.ResponseWriter, r *http.Request, params GetStatusParams)
+	// Get the incidents shown on the status page
+	// (GET /status-page/incidents)
+	ListStatusPageIncidents(w http.ResponseWriter, r *http.Request)
+	// Post an update about a status page incident
+	// (POST /status-page/incidents/{id}/updates)
+	AddStatusPageUpdate(w http.ResponseWriter, r *http.Request, id int64)
+	// List all targets, ordered by ID
+	// (GET /targets)
+	ListTargets(w http.ResponseWriter, r *http.Request, params ListTargetsParams)
+	// Register a new target
+	// (POST /targets)
+	CreateTarget(w http.ResponseWriter, r *http.Request)
+	// Remove a target
+	// (DELETE /targets/{id})
+	DeleteTarget(w http.ResponseWriter, r *http.Request, id string)
+	// Get a single target
+	// (GET /targets/{id})
+	GetTarget(w http.ResponseWriter, r *http.Request, id string)
+	// Change some fields of a target
+	// (PATCH /targets/{id})
+	UpdateTarget(w http.ResponseWriter, r *http.Request, id string)
+	// Create or replace a target
+	// (PUT /targets/{id})
+	ReplaceTarget(w http.ResponseWriter, r *http.Request, id string)
+	// Check a single target now
+	// (POST /targets/{id}/check)
+	CheckTarget(w http.ResponseWriter, r *http.Request, id string)
+	// Get the latest check results of a target, oldest first
+	// (GET /targets/{id}/history)
+	GetTargetHistory(w http.ResponseWriter, r *http.Request, id string)
+	// Import many targets at once
+	// (POST /targets:bulk)
+	BulkImportTargets(w http.ResponseWriter, r *http.Request, params BulkImportTargetsParams)
+	// Export the targets registered through the API in a format accepted by the bulk import
+	// (GET /targets:export)
+	ExportTargets(w http.ResponseWriter, r *http.Request, params ExportTargetsParams)
+	// Unregister a URL from health checking
+	// (DELETE /unregister/{id})
+	UnregisterTarget(w http.ResponseWriter, r *http.Request, id string)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// StreamEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read-only"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"read-only"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamEventsParams
+
+	// ------------- Optional query parameter "target" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target", r.URL.Query(), &params.Target)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "target", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "label" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "label", r.URL.Query(), &params.Label)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "label", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read-only"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"read-only"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealth(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListIncidents operation middleware
+func (siw *ServerInterfaceWrapper) ListIncidents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read-only"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"read-only"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListIncidentsParams
+
+	// ------------- Optional query parameter "target" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target", r.URL.Query(), &params.Target)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "target", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "open" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "open", r.URL.Query(), &params.Open)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "open", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListIncidents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFailedNotifications operation middleware
+func (siw *ServerInterfaceWrapper) GetFailedNotifications(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read-only"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"read-only"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFailedNotifications(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// TestNotifications operation middleware
+func (siw *ServerInterfaceWrapper) TestNotifications(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TestNotificationsParams
+
+	// ------------- Optional query parameter "channel" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "channel", r.URL.Query(), &params.Channel)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "channel", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TestNotifications(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RegisterTarget operation middleware
+func (siw *ServerInterfaceWrapper) RegisterTarget(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"operator"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"operator"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegisterTarget(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListSilences operation middleware
+func (siw *ServerInterfaceWrapper) ListSilences(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read-only"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"read-only"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSilences(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateSilence operation middleware
+func (siw *ServerInterfaceWrapper) CreateSilence(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"operator"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"operator"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSilence(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteSilence operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSilence(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"operator"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"operator"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSilence(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetStatus(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read-only"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"read-only"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatusParams
+
+	// ------------- Optional query parameter "fresh" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fresh", r.URL.Query(), &params.Fresh)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fresh", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "healthy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "healthy", r.URL.Query(), &params.Healthy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "healthy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "label" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "label", r.URL.Query(), &params.Label)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "label", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "idPrefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "idPrefix", r.URL.Query(), &params.IdPrefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "idPrefix", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStatus(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListStatusPageIncidents operation middleware
+func (siw *ServerInterfaceWrapper) ListStatusPageIncidents(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read-only"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"read-only"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListStatusPageIncidents(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddStatusPageUpdate operation middleware
+func (siw *ServerInterfaceWrapper) AddStatusPageUpdate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"operator"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"operator"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddStatusPageUpdate(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListTargets operation middleware
+func (siw *ServerInterfaceWrapper) ListTargets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read-only"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"read-only"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTargetsParams
+
+	// ------------- Optional query parameter "label" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "label", r.URL.Query(), &params.Label)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "label", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "idPrefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "idPrefix", r.URL.Query(), &params.IdPrefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "idPrefix", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTargets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateTarget operation middleware
+func (siw *ServerInterfaceWrapper) CreateTarget(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"operator"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"operator"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateTarget(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteTarget operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTarget(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"operator"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"operator"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTarget(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTarget operation middleware
+func (siw *ServerInterfaceWrapper) GetTarget(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read-only"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"read-only"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTarget(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateTarget operation middleware
+func (siw *ServerInterfaceWrapper) UpdateTarget(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"operator"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"operator"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateTarget(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReplaceTarget operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceTarget(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"operator"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"operator"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceTarget(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CheckTarget operation middleware
+func (siw *ServerInterfaceWrapper) CheckTarget(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"operator"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"operator"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CheckTarget(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTargetHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetTargetHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read-only"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"read-only"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTargetHistory(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BulkImportTargets operation middleware
+func (siw *ServerInterfaceWrapper) BulkImportTargets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params BulkImportTargetsParams
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", r.URL.Query(), &params.Mode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", r.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dryRun", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BulkImportTargets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportTargets operation middleware
+func (siw *ServerInterfaceWrapper) ExportTargets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read-only"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"read-only"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportTargetsParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportTargets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UnregisterTarget operation middleware
+func (siw *ServerInterfaceWrapper) UnregisterTarget(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"operator"})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"operator"})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.UnregisterTarget(w, r, id)
@@ -289,9 +5459,28 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	m.HandleFunc("GET "+options.BaseURL+"/events", wrapper.StreamEvents)
 	m.HandleFunc("GET "+options.BaseURL+"/health", wrapper.GetHealth)
+	m.HandleFunc("GET "+options.BaseURL+"/incidents", wrapper.ListIncidents)
+	m.HandleFunc("GET "+options.BaseURL+"/notifications/failed", wrapper.GetFailedNotifications)
+	m.HandleFunc("POST "+options.BaseURL+"/notifications/test", wrapper.TestNotifications)
 	m.HandleFunc("POST "+options.BaseURL+"/register", wrapper.RegisterTarget)
+	m.HandleFunc("GET "+options.BaseURL+"/silences", wrapper.ListSilences)
+	m.HandleFunc("POST "+options.BaseURL+"/silences", wrapper.CreateSilence)
+	m.HandleFunc("DELETE "+options.BaseURL+"/silences/{id}", wrapper.DeleteSilence)
 	m.HandleFunc("GET "+options.BaseURL+"/status", wrapper.GetStatus)
+	m.HandleFunc("GET "+options.BaseURL+"/status-page/incidents", wrapper.ListStatusPageIncidents)
+	m.HandleFunc("POST "+options.BaseURL+"/status-page/incidents/{id}/updates", wrapper.AddStatusPageUpdate)
+	m.HandleFunc("GET "+options.BaseURL+"/targets", wrapper.ListTargets)
+	m.HandleFunc("POST "+options.BaseURL+"/targets", wrapper.CreateTarget)
+	m.HandleFunc("DELETE "+options.BaseURL+"/targets/{id}", wrapper.DeleteTarget)
+	m.HandleFunc("GET "+options.BaseURL+"/targets/{id}", wrapper.GetTarget)
+	m.HandleFunc("PATCH "+options.BaseURL+"/targets/{id}", wrapper.UpdateTarget)
+	m.HandleFunc("PUT "+options.BaseURL+"/targets/{id}", wrapper.ReplaceTarget)
+	m.HandleFunc("POST "+options.BaseURL+"/targets/{id}/check", wrapper.CheckTarget)
+	m.HandleFunc("GET "+options.BaseURL+"/targets/{id}/history", wrapper.GetTargetHistory)
+	m.HandleFunc("POST "+options.BaseURL+"/targets:bulk", wrapper.BulkImportTargets)
+	m.HandleFunc("GET "+options.BaseURL+"/targets:export", wrapper.ExportTargets)
 	m.HandleFunc("DELETE "+options.BaseURL+"/unregister/{id}", wrapper.UnregisterTarget)
 
 	return m
@@ -300,23 +5489,101 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8RX32/cNgz+VwRtj1583VKg8Fu6Xw2QDcE1wR6KYFAs+qxOllyKTnAI/L8PlGyfr3aW",
-	"tGu3p7Nj6iP58SOpPMjSN6134CjI4kEihNa7APHltdJb+NBBIH4rvSNw8VG1rTWlIuNd/j54x38LZQ2N",
-	"4qdvESpZyG/yA3Sevob8Z0SPsu/7TGoIJZqWQWTBvgQOzvpMnjsCdMq+BbwDTKe+egyjUxGiVwHJMJO/",
-	"ezqz1t+D/vpB/AZUey2cJ6EGnymCX3zn/gP/Wwi+wxJiBFX0yUbDOYadqtGibwHJJLWUXgP/HsNFY8Hf",
-	"RCBFphSVR0E1JHYF7VuQmYw/hQyExu044QZCULtHAYfP4t5QLaoOqQYUGkgZG5ZwfSZZWwa5gO9SpAcX",
-	"N5O9v30PZdTfG1CW6h9rKP/aQugsLfPVHUbi/wxQeqfDMtSfBgvhq5hxHUFFyajCODEezGTlsVEkC1lZ",
-	"r+iQgOuaW4gShJH0f2LDrPiplLGg1yhOZvsl6B81RD4ZixTugIQJovQuGA0IWownJ9Bb7y0ox6hGLwGv",
-	"BhANjkxlANfCYXl0Kyy+ubq6FOlj0lGFvlnkeUA0jmCXSCPTQCDVtKs5uiVZ9yqIFpDLETmb6qIVwXcM",
-	"txZ5h3Yl5xpE450hz4xdby/mcB2aJ1VqOACGnqg5lGyeWrZU4pqiUw2WMl6r17UzHzqY1Wtq2iSHTyLh",
-	"enshyIvbGR2fSkViweiVxNjUuMovfZ9dnsewEXYmEDCwUE6PUfArhzavfxwdhiw7SBNAxBEAKM4uz2Um",
-	"7wBDQn9xsjl5wXn7FpxqjSzkDyebk43MZKuojtTmCZsfB+qZ+Fiqcy0L+StQ8iKz48X7/eZ0PR8Tpt7r",
-	"M/lys3lszE9w+domjQO9axqF+xTGvBOGVhtmFufN5vlIY1SQDyvpbAeLq1Ejwz5/7fX+i+2tAbw/lghh",
-	"B/2CxM2jkyh0ZQkhVJ21+0kgadOePofU2c0oHnn59JHZHeLLlW6kXCjh4D7qmSU/1/TYT/lhvj4mxrfj",
-	"mFnj8dnVMwRNeKqMy/3aT62tENV+7WpyYQKxLo9GNsbj4bPqsGiDI+ShEZhQZe1MJsMUDInXzo1f8gej",
-	"+6Q5CwRLjq8n06lHWoWqAQIMsni3Nj67xSweOnPYzOTFIQKeknyQR5DMpFNN3Ihaftws2axgH8/em89t",
-	"pEMc/6KVTp9VwnQZ/l9771BMoVLn8b1k2Xp8KIKsVfjCl8oKDXdgfduAo+F/j2H1F7Imaos8t2xX+0DF",
-	"q82rjexv+r8DAAD//7AE+ne6DQAA",
+	"H4sIAAAAAAAC/+w9W3PbNrp/BcuzM3mhZKWb7tlqpg9u7HbduknGdk46J/LJQOQnCw0JsAAoW5vRfz/z",
+	"4UKCInRLY9dN8+IxRRKX734FPySZKCvBgWuVjD8kc6A5SPPvL4MXcKcHz2uphMQfclCZZJVmgifjxP5O",
+	"xIzoORAOd5pU9AZSUjKlGL8hgps7BVX2TpImKptDSXEsvawgGSdKS8ZvktVqlSYVlbQE7WbfNO3VHEhn",
+	"ZcQu2S+kkrBgolZuMfiT0HOQ5Lca5JK0k5CyVpooTZfmIUVLXCHDOcyjSZpw/G2cZHYp25afJmf5Kwkz",
+	"dtdf8UteLIkEXUtONJU3oBW5nQsF5OwE55d4zfTcLx8HiS+E+Tm2L+WcTqG4hAIyLeR+6ympzuaINFoU",
+	"RLlX1ZAcNxeEKfIelt8uaFFDiv/+LfifaEEk/FYzCRNOSYErIEKSv7l7cJcVdQ6E6SG5hAVIGkxDMsrJ",
+	"FMgNWwAnmShLShQgpjTkOMoU11sB1bhCAyWPxeGEJ2kCd7SsCkjGCfDFt5UUeaqBln/7tqLL0lB2HJ5m",
+	"mR1gMg2likA19T9QKenSQpmVTPeh+zO9Y2VdEl6XU0uVZkxSgXQkiSC2v1EJDhGQEzYjomRaQ75ptWbC",
+	"cLWlnSsZPx2NRmlSMu4um+UyruEGpGUwCaoSXIHZ4Hc0v4DfalBmD5ngGrj5l1ZVwTKK2zn6VeGePgRT",
+	"/l3CLBkn/3XUio0je1cdnUop3FRdmHxHcyLdZKs0eS74rGDZA0x8AUrUMgNCCwk0XxK4Y0orXMT3Qk5Z",
+	"ngO//1W8rECaAQkXGtEvbiG34pEpIv0ahWzFgBQFeImWSciBa0YLs/AzrkFyWlyCXIC0k977FvykRJlZ",
+	"CdgH0+SF0Md2Q/e/iJ9Bz0UeAtGt4HtR8/wBqQlXMDNzrtLkSoifKV86ZlL3v4wrIUhJ+ZIIPsihpDwn",
+	"2Ryy9yolErRcEjrTIK1WboSQgkzwXBFmlfIFPjg4Ng9a/Zmkoe4P7vdF3KUbq+aaFZZc7eZRR3jypjeU",
+	"8ZiiCkTSKk1ec1rruZDsPw9CQd40kYTxBS1Yvs5dr7mqq0pIDfnPkDN6ZRZ97ygNYDgV+ZLMhCypgSfS",
+	"WrMko3jcaEaI18X7sxLvXYCqC7O8SooKpGZWzGcSUIv2kXh2oryA8UaAnlNNbkECoXlu1dCe6hD3U8Dh",
+	"80goxQLylAi0SRhHNV/QDEgpcjhsfrm8qHl/+jdzMNafEaRzym9A2anNjIijWiO1cidXELdm626CqRAF",
+	"UI5T1NwOcMAmvdox5hXk5gFm8HXQ5uoqpx8DXAPLQ/BojARjxeXJ+K0HatpQUbuUFuMhYK6bAcX0V8iM",
+	"tm801BphIoZ7GzIPE7yHlrFmGXKC2Z/ROMSMnva3UYJS9GbjgO621a+zWhqSyEFTVqj+cGtQyCwt+imi",
+	"e1wA115UdBdgRPOYUCujyYxxpuZI8rg/wBsWc8SZgXOghZ4vUUTV3F2kE854xnLgekwoJ7QAqcktVUQB",
+	"1/ioBCWKBeTWHOZoBL61UydpYmYyRqUdI7le33Ka3A3wrcGCSrQ2Fb5uNvXcjWEuLt1A5uKsGQ2tKcoK",
+	"yF8IzWZONPYxTrWGstKqD6MXjZrKoWAL9NOah/umbJoguXEoIgPRsjGbeLAY4t5ICQxvhgRKyox7oqGA",
+	"G0nLGEk5mn9HdVSq8P4kiJDfaqgNU1gJnowT5JeBZmWUbmcGbgfOYf2kuiKC7z0Ri0iPEF3E4JLNGMjY",
+	"6+8Zz7cL194qGzoVklBLoLU3glkGAZ2ax5I0cUTcJ89VmmAU4R14URLj8caRmi7buMM6PcX2ZvnvXQxC",
+	"Zw1QumJ2w46noo7OUMsiHsgoBWdaSMjJ64vzEJe1ZDvlEkMy86zgUBTuxs6bJgErBTQd0l5MpP3biB7D",
+	"/ptsC4M32EIXfVGFejYA45wq/kQTCZlYAIJBMZ5BVPtmgivIas0W8A5XXkvYKkns7ggaS3mN/1kbGY0M",
+	"SqS4jQqWvLaO2jtnLvcnOHFPeHqwEtoJd8a9nR3iclYIGpCFtclxtq3k7FUWi8xj9xajNKcwtrOqAz5T",
+	"BIHKcgN5/2YM9jHWuHKDbBUbJsCidpnF5/Ypz+XWmNgiEN36DYNv0Zp7y0aj8SqaQVyhmFvhxFMoBL9R",
+	"RIs22OnNlBxmtC40aceMTFgBz/HffbB0a1nEIB5ysgSdEqaVMR9qlRLclNK0rAxveQI2oSXkeiMLeRSr",
+	"1pndhRyL50v77MoaE3WEMf59dfXKLcrabzMpyh7pRrmu2cIWnHfoH8VJBRKxe4CuvUcpbAWtA03LheHW",
+	"IsIlKnaZ0kIuT7mWy77EjcmndvOinhawVdBskxcRAtkT1SkZeTHlndh1CbUJ2/tgbg3gIUhbQDew3wvK",
+	"je3ag/AGmfxmvlxjylbOkFtPpN7ANvdFBTwupFne2Trj+p/PoqDqCKbeMHYCZzvuxwLO394ueDq78I4F",
+	"mUJGawVG9gRQcCMSSd37lHt1bh2LPln5IaNWr4/Q3M5ZAd3lMAvTvfldsQJ4tmu3gYFSV5UEpawJSYl7",
+	"P7qHjs24SdIcLkf6xluL5GBDLSJjxH3eKF2a5wy3TItXHSLvLbgLHjsApm1siqaJLUyXQ/ITLJXNWllv",
+	"mpICtAaJqjdnN8zYeCkp2HsgJj+kUlLSpY+3cE0Zn3D7jkrtKyolT4ZPUvLkHf4ZPDGq7MnRk7XszocE",
+	"+CIZI8caYAEt8crneFYRWLyA20uHxkgEoiydDNjG7DZNE8C+ZPwc+I2eh4mWFpbAc3V8AE/aJNT4QwwJ",
+	"QY5Mz9tFtGk7TGGa4FJKFIDze/DFtWznQeEmg123h7XIr8G7mIWrSQkXt93s1X4bd5vYGtVa37HNnvWy",
+	"lnbPnTV8ZMjLE0WDx+sNVGX0zSt6A69NRKxPXhtDUpdzcctJVU8LlhVLF5K4ndtwL53NIPMRSaNYKJmx",
+	"O7wFd5W5tYsE13a0LW4VRgCuQG2OIn9swCWG94O8eDeOty8af36zC7Q7VKGWXM8Bg4tNgKIXnmhc1gPj",
+	"FKrOMlDqwFiJ21Y08LxOoOsOv58xht9A9tGieDlLxm+3W/uBvFylG3IJ30Xcy+cFA65t8Nk91pUR1hdB",
+	"jSFqTTDnA1w7ENybjRRTsY1wS4P99GGHQc2Wxzfbiw0Mo6Jm7114m+h4p0kkeJsY9nM/UY1IZKicWVEY",
+	"BrEW2J7WEsLFL2C/VzTTRdw0tVkC1amo2EZ2PWm6S0zb2FcDe7+WcBvtKqKcEZHf+7NIf7n9YPf+cLQZ",
+	"0O1MVQnlecpuK7WZQaNLXKWVc8lcrdV2XqB6A9Fbf79P6IeER1NSc/ZbbfMtjBufIQyIVFRrkDjC/709",
+	"HvwvHfxnNPjmuv333XBw/WGUPv3qv1d//xRhpfsO8EigOVZVJWMta4gx10eFWpBeRK3P+CVkkciFvYuQ",
+	"R1+F3xTQC0OmBB0xyXJQTmLwGbuppQ+HXoUzbC8g2hI/eX1xjs7CNIikHBpFsc4Oizs0Fi6v0NjrL+B7",
+	"BkVunBUbN0y9DUhm9g6VQAqYaUINDJb4Q5KuUfehFPUpUDMiEpSR2RqXfwh+Rg+Bnw14uGxouWfgSFgP",
+	"kki4YUqDhDxtVFQOWUFxi4wHmyYz1G4ZxWDn1OfqUeBJUd/YoqjjV2dhdtO8lqQJrVhyvYsJoxlOu5Xn",
+	"fiB7ibO0gvCysgj+48nlT8TJfSZGGQhZLZleXiJ0nJKs2E+wjFWoNjbO8aszrC9V4YYxhVPr+dC+3hR3",
+	"NnVMrl7yl8Hxq7MBTtAaE3bCVZpMgUqQx7U2IsVefe/3+eObq2Q9KHJMfnxzRRS74T4+hKWtbpU/vvnp",
+	"0hFwf5m/3jZVm8awN3O1a5prXdmaIMZnog8MhMBMyIaVTJUuzz2G8BLRFgbI1XDCJ/zKu8x6Dkx668BV",
+	"uvQ8NeW0HuKfttptSE6Nv+UDu8LUMoLLo1linfDm8bEBh+CwXrnoal2RaIx7i6pVyOZpm9J2AuGXQauV",
+	"LVKH5I31HCYcmJ6DTOO6GM3fWkE+JHbzxIQSMPLEAXJH2l3bxAKn3e+EN9bk2CVBicmCgnQCaAFSWdQ8",
+	"HY6GT30oFsXQOPnHcDQcWRNnbmj8CBa+tt7ZVV30/gRQNSzNIbPJxQq4wZQCnptUuhnFBAQA6y/Xykkw",
+	"LhLWkSifEJvwTh2JycXzXkgVpXMT6BWSZIVQO0O+Q4ISI6ulxJGQugzTgiGXJjJj35tw5hPAilD3sN3S",
+	"jEml7Vg51dQMQbO5u4sREdJLRaftDix0J7wNdRZLMqcL6OzTmAJDYg1qVyNlVc17gApLKZA8c6YcDiC3",
+	"dCB85e5ZbqJfEmh5unAV5WG/wttokT1iz63QSQrVaMezk01V6faBjyxL37wOOzsGDZTDE/fxNPvjzgJ0",
+	"V3wVWdbWosemNiq63NirLWSPur0Mq+u1KvavRqO16kwNd9ry3EAZfHXLM4NWgYUppzK0OOFIe2PyYZKw",
+	"fJKMJ0m5HCiQC5bBJEknqNXMzyis1fjoqL09zERpHrHydZKMvx79I5341NQkGc9ooSAdDocrlMpuWk+a",
+	"O2duHsR7llkniRstoox7wXxLtC3GEQvPLNRioG+gexQ0CJhXnu5+pVPMa176x+6X2kJ888bXu98Ias5D",
+	"w8JwoTcp3hp7cICyH625Dx2F3715nSaqLksqly24Gm1qK0apcoXvA9UKHTP5kX0yEO9dmfEDaCu+kh7l",
+	"PovretYI7weE+9f7UESs8+CTI+AHV13lMOCsltYatFD3XLFZrx7H1JyPqDsZjDEyRfQtQ8vB1QUZtdsq",
+	"Q463mgolRWwjlFeBE35lMi4alCZfjUbNlNbeeQ+VxoFLKIVcpo37SQqBphTHeTSVOqZszplqSiz30zau",
+	"patdggEaU63G2V/h9Gz8bTMaa0VIa2g1oGPB4mOzulxub8428L1b2G8vxd9LPXkYR7RTPzHKlPHS2q39",
+	"hSSjZ0xH7Q0MUsLhFpSz5Cx7hg6GOnK5mi0isl8+rJKHQH5/3kPIwO6r402hn17kATT+WvTRAYUzs0Vd",
+	"GBih69XkuVyPEhqfErRkoGKEo113YiVUhG4wYblOMrulpCFePfcu5+7C8GhHcJOE2ywyH0R6bUjg7kHE",
+	"Jz6TKmqdiRJMg6rbVz9IYHKND0nMz/Yi5u99E94npH6al4zHKN/f6NqLwHNCg5Tyycs3L1xeGaF4cXr5",
+	"8vx/Tk98WjmMZ0bDMJYPfLAnpP4cKgmZbQSyIc51jPr7aHPM6ELUJi/z6uXlFTny1RbrdsaFm+nKGwEu",
+	"0vOdyJefrPHNDb7qBgtxE6t9zGL7OnGZ7lldGDvDR5Y/M48GX/lm9ytNE/U9We+WSoSMcUJwr8MMnpYw",
+	"gAi3Jiw5E9Kb8iZi5UPGR64yQG00C1DLXvqHHkKWtoUPe1sAfhMYWLNl9NMlAZ4/Zvq6L7uQZtibYYRe",
+	"XWWixOhfg+RVGoixTo7bdS4HjlMbM0P8Ml4D+t+1qmnhGkW5sBLWleYB10Ny0VTw2F+FC3zpCZ8JGYwf",
+	"dLna/pUh8WQWcdrMcvZw155LoBoum5LR+5CiYXHOPpL06SebuTPtWnzJ3vJVP3+Z8NLHyEcPq3Xlb111",
+	"lx6dmX45U+zTlZRHH1i+shxUgK1Y6dLgifm9pcE1Y9gYspiXCA+USdbJKDRpdxYura73Ud9+28Bz+KtY",
+	"kR9DHqfWlGx4DXHftGBEg1xGlyvXeYGWfxMpM81RNkckcXtpv0F8ra2oLZQQNYq4N6ZXWoKaf4t00Sm8",
+	"xUf9qzaBExGJP4C+9F0ZW/0yowM645uKYq400Bw3ZONM/vAfF4BwG97goZmFd/wzlyz08fhIneW2GFfQ",
+	"YGY0S9v90VQmu66uDpA8PDesMuhf2RwGOzhJsvuF5rCqVRqtpzG1/0JqMl2m7kgqDJ4iRQyMgMJ33HaN",
+	"5TMkV8zpz8ASOjsZbti4skcgRLBjZZIv8jAXA/MX0c4zHGPQ/otk/tz2C5rfm6vrPQtApDZhUfvPCajM",
+	"XZw3UwRXnfvBxN0fzFPXe+HNnOi0x4PuMLSHiSn0O38PsIg7fXoti247Vi62Fvf8Uffh1erzsi7S5NlX",
+	"e7h66ycM3XdoN4ZDxG0goUPtNMCC0z0yMmdhYiKoVW3rmFXbNGr01zcjktPlWoTZmPqVOYDICKRno2eY",
+	"uuZiwsNBWVgyNCRGltMFZQWdFmDKBcNiAGrV4RRV5XoFzIbkTL86/IHc1N68jz918cfZYb+PIVqvcWOh",
+	"9WZGMPb6UVAKH/eATQeyb/Zfm8g2CXWYBf1RV1I9A8jVsEecx3neK1B/OGfgftze9f08tP8b4bk+j9m1",
+	"ucOzHrmm+nN5Rq+E0oRy1/pgD1ohtMMXrEVMmhwFvYUbg4tXTUR8jTfu1dr+U5qEPn6/v55p0w1fTL+H",
+	"0FgG7oGBlnb9sDAAGwtcPprsz9N7mTWaTmoPtQtI9Fy0x5etSfeL8+BEVtvhuLtwZvUlOfU4k1Pak0er",
+	"LfYMsAa8smfi0p/W8Dkp+MeEWIRuU9WHS9tUZ7QJdaOHkDpzCFb4xfXa4no1XVAtvLYGkK/mTVcH29CS",
+	"mqQf4fJESnoq34XYpS1r+j+AFrVdkHup0tHDqVJ/Kuxf3Ol5PDLRRqKJEiX4PljTXRgwVB0RkRf2yOAH",
+	"oGPTV/nYyLg5MXmV/jGm6OdmLD4adjDwtQe9GBQTutEAPLIHFW6N19mgODbIidLlX+0QT5QPovvzGae1",
+	"JrkAe4ijqLkmWtxSmStbfmJTU2sumWno9Tz4WDTfvbFlJN8U/fYCQtw7YPjwZ6Y6PnUq6OPUhsnEd80v",
+	"zMZH2GRuT6rcmO55XflTDUwu55+jJpV0SGtMrKrAsoY7KfNz4ZD9UrPh6aB7ROOC7qRuRvaLB7J3NrSf",
+	"Bm1Ouem2egQcMp7WxRYVcrxWSmO+REL9qW/uGxREixt7ZJg7Z9Ex5a1kGjxrOQ5FqgD8SsCMUL4MDnj2",
+	"XznhQpt2bMyK2pM1YmnN9nMiG2Pj3Y3UlTKF7nmuTFDF9s4ZDavst5aCgpi0Vb5hs7YNjqgJD49cbKu7",
+	"108AafK17ck8Pmdsv6wx4Z6f12pO3DdFYjUndh9B3Unzg1ty5Ky3jfVCuIju90b8qWdVVSxdFdOmzpLm",
+	"kxsHFC79jrzb74v+p53Rl7QsPu3oppc7U4vuqGu85L//h72bzfezMlHUJVeE5SmppW1oEVVDc+F5LCmZ",
+	"CffNounSHEaBI1UgW+0QabJ+OK+l942fDXLeU1tT35fazxwRSnK5JLLm5Nb0Yk0h9TLm82udePr1PiuL",
+	"fOPpnryVw7qJLJ7t170aBaFNF3JXv8AdPrgxvXl6t1WIRwsmbXo/Lh8NxbbS0V0ahk8TZNCIePxjE5N/",
+	"jGjaeRpDGwSOZei+5Dc3GmWWosmeNoKRepaiCc0yqHR7oiyaZs5UsDxVcz9SJAH0sU13J6fnp1enpJta",
+	"Wre3XjczfzJvHw3DdkP35Nns2aLXruNLaPjxxMJaoiPUtuih/dzv0TOzykWcEjFNXpAcFlCIqgSu3fko",
+	"7tx6e6rZ+OiowOfmQunxv0b/GuEJo/8/AIm5HWRxegAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -2,17 +2,57 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"log/slog"
+	"maps"
+	"math"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"golang.org/x/time/rate"
 )
 
 //go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=openapi.config.yml openapi.yml
 var _ ServerInterface = &Server{}
 
+// On-demand checks hit the targets as well, so they are rate limited
+const (
+	freshStatusInterval = 10 * time.Second // full rounds through /status?fresh=true
+	targetCheckInterval = 5 * time.Second  // checks of a single target through /targets/{id}/check
+)
+
+// eventKeepAliveInterval is how often idle event streams get a comment, so proxies don't close them
+const eventKeepAliveInterval = 15 * time.Second
+
 type Server struct {
-	checker *HealthChecker
+	checker       *HealthChecker
+	monitor       *HealthMonitor
+	notifications *NotificationQueue
+	statusPage    *StatusPage // nil without a status page
+
+	limitersMu sync.Mutex
+	limiters   map[string]*rate.Limiter
+}
+
+// NewServer creates the API server
+func NewServer(checker *HealthChecker, monitor *HealthMonitor, notifications *NotificationQueue) *Server {
+	return &Server{
+		checker:       checker,
+		monitor:       monitor,
+		notifications: notifications,
+		limiters:      make(map[string]*rate.Limiter),
+	}
+}
+
+// SetStatusPage enables the endpoints for the incidents of the status page
+func (s *Server) SetStatusPage(page *StatusPage) {
+	s.statusPage = page
 }
 
 func (s *Server) GetHealth(w http.ResponseWriter, r *http.Request) {
@@ -20,24 +60,26 @@ func (s *Server) GetHealth(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) RegisterTarget(w http.ResponseWriter, r *http.Request) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
 	var target Target
 	if err := json.NewDecoder(r.Body).Decode(&target); err != nil {
 		respondError(w, r, ErrParseJsonBody(err.Error(), err))
 		return
 	}
 
-	// Validate URL
-	parsedURL, err := url.Parse(target.Url)
-	if err != nil {
-		respondError(w, r, ErrInvalidUrl("", err))
+	healthTarget, apiErr := s.newHealthTarget(target.Id, target.Url, target.TimeoutInSec, target.Labels)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
 		return
 	}
+	healthTarget.Namespace = namespace
 
-	apiErr := s.checker.AddTarget(HealthTarget{
-		URL:       parsedURL,
-		URLString: target.Url,
-		ID:        target.Id,
-	})
+	apiErr = s.checker.AddTarget(r.Context(), healthTarget)
 	if apiErr != nil {
 		respondError(w, r, apiErr)
 		return
@@ -47,61 +89,956 @@ func (s *Server) RegisterTarget(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) UnregisterTarget(w http.ResponseWriter, r *http.Request, id string) {
-	apiErr := s.checker.RemoveTarget(id)
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	key := targetKey(namespace, id)
+	apiErr = s.checker.RemoveTarget(r.Context(), key)
 	if apiErr != nil {
 		respondError(w, r, apiErr)
 		return
 	}
+	s.forgetLimiter("check:" + key)
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func (s *Server) GetStatus(w http.ResponseWriter, r *http.Request) {
-	results := s.checker.CheckAll()
+func (s *Server) ListTargets(w http.ResponseWriter, r *http.Request, params ListTargetsParams) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
 
-	type JSONResult struct {
-		ID              string    `json:"id"`
-		URL             string    `json:"url"`
-		Status          int       `json:"status"`
-		Healthy         bool      `json:"healthy"`
-		Timestamp       time.Time `json:"timestamp"`
-		DurationSeconds float64   `json:"duration_seconds"`
-		Error           *string   `json:"error,omitempty"`
+	selector, err := parseLabelSelector(derefOrZero(params.Label))
+	if err != nil {
+		respondError(w, r, ErrInvalidQuery(err.Error(), err))
+		return
 	}
 
-	jsonResults := make([]JSONResult, len(results))
-	for i, result := range results {
-		jsonResult := JSONResult{
-			ID:              result.Target.ID,
-			URL:             result.Target.URL.String(),
-			Status:          result.Status,
-			Healthy:         result.Healthy,
+	var targets []HealthTarget
+	for _, target := range s.checker.NamespaceTargets(namespace) {
+		if matchesTarget(target, selector, params.IdPrefix) {
+			targets = append(targets, target)
+		}
+	}
+
+	page := listPage{sort: string(SortID), limit: params.Limit, cursor: params.Cursor}
+	targets, next, err := paginate(targets, page,
+		func(HealthTarget) int64 { return 0 },
+		func(target HealthTarget) string { return target.ID },
+	)
+	if err != nil {
+		respondError(w, r, ErrInvalidQuery(err.Error(), err))
+		return
+	}
+
+	result := make([]Target, len(targets))
+	for i, target := range targets {
+		result[i] = toTarget(target)
+	}
+
+	setNextCursor(w, next)
+	respondJSON(w, r, http.StatusOK, result)
+}
+
+func (s *Server) CreateTarget(w http.ResponseWriter, r *http.Request) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	var target Target
+	if err := json.NewDecoder(r.Body).Decode(&target); err != nil {
+		respondError(w, r, ErrParseJsonBody(err.Error(), err))
+		return
+	}
+
+	healthTarget, apiErr := s.newHealthTarget(target.Id, target.Url, target.TimeoutInSec, target.Labels)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+	healthTarget.Namespace = namespace
+
+	apiErr = s.checker.AddTarget(r.Context(), healthTarget)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	created, _ := s.checker.Target(healthTarget.Key())
+	w.Header().Set("Location", "/targets/"+url.PathEscape(created.ID))
+	respondJSON(w, r, http.StatusCreated, toTarget(created))
+}
+
+// maxBulkImportSize limits the request body of a bulk import
+const maxBulkImportSize = 10 << 20
+
+func (s *Server) BulkImportTargets(w http.ResponseWriter, r *http.Request, params BulkImportTargetsParams) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	format, ok := targetFormatForContentType(r.Header.Get("Content-Type"))
+	if !ok {
+		respondError(w, r, ErrUnsupportedMediaType("expected application/json, application/yaml or text/csv", nil))
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBulkImportSize))
+	if err != nil {
+		respondError(w, r, ErrParseBody(err.Error(), err))
+		return
+	}
+	targets, err := decodeTargets(format, data)
+	if err != nil {
+		respondError(w, r, ErrParseBody(err.Error(), err))
+		return
+	}
+
+	healthTargets := make([]HealthTarget, len(targets))
+	for i, target := range targets {
+		healthTarget, apiErr := s.newHealthTarget(target.Id, target.Url, target.TimeoutInSec, target.Labels)
+		if apiErr != nil {
+			apiErr.Message = fmt.Sprintf("target %d: %s", i+1, apiErr.Message)
+			respondError(w, r, apiErr)
+			return
+		}
+		healthTargets[i] = healthTarget
+	}
+
+	replace := params.Mode != nil && *params.Mode == Replace
+	dryRun := params.DryRun != nil && *params.DryRun
+	diff, apiErr := s.checker.ApplyTargets(r.Context(), namespace, healthTargets, replace, dryRun)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, BulkImportResult{
+		DryRun:    dryRun,
+		Created:   nonNil(diff.Created),
+		Updated:   nonNil(diff.Updated),
+		Deleted:   nonNil(diff.Deleted),
+		Unchanged: nonNil(diff.Unchanged),
+	})
+}
+
+func (s *Server) ExportTargets(w http.ResponseWriter, r *http.Request, params ExportTargetsParams) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	format := TargetFormatJSON
+	if params.Format != nil {
+		format = string(*params.Format)
+	}
+	contentType, ok := targetFormatContentTypes[format]
+	if !ok {
+		respondError(w, r, ErrInvalidFormat(fmt.Sprintf("unknown format %q", format), nil))
+		return
+	}
+
+	// Config targets are managed in the config file, exporting them would make the export fail to import
+	var targets []Target
+	for _, target := range s.checker.NamespaceTargets(namespace) {
+		if target.Source == TargetSourceAPI {
+			targets = append(targets, toTarget(target))
+		}
+	}
+
+	data, err := encodeTargets(format, nonNil(targets))
+	if err != nil {
+		respondError(w, r, ErrEncodeJsonBody("", err))
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="targets.%s"`, format))
+	_, _ = w.Write(data)
+}
+
+func (s *Server) GetTarget(w http.ResponseWriter, r *http.Request, id string) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	target, ok := s.checker.Target(targetKey(namespace, id))
+	if !ok {
+		respondError(w, r, ErrUnknownTarget("", nil))
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, toTarget(target))
+}
+
+func (s *Server) ReplaceTarget(w http.ResponseWriter, r *http.Request, id string) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	var spec TargetSpec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		respondError(w, r, ErrParseJsonBody(err.Error(), err))
+		return
+	}
+
+	healthTarget, apiErr := s.newHealthTarget(id, spec.Url, spec.TimeoutInSec, spec.Labels)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+	healthTarget.Namespace = namespace
+
+	created, apiErr := s.checker.PutTarget(r.Context(), healthTarget)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	target, _ := s.checker.Target(healthTarget.Key())
+	respondJSON(w, r, status, toTarget(target))
+}
+
+func (s *Server) UpdateTarget(w http.ResponseWriter, r *http.Request, id string) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	var patch TargetPatch
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		respondError(w, r, ErrParseJsonBody(err.Error(), err))
+		return
+	}
+
+	target, apiErr := s.checker.UpdateTarget(r.Context(), targetKey(namespace, id), func(target *HealthTarget) *ApiError {
+		rawURL := target.URLString
+		if patch.Url != nil {
+			rawURL = *patch.Url
+		}
+		timeoutInSec := &target.TimeoutInSec
+		if patch.TimeoutInSec != nil {
+			timeoutInSec = patch.TimeoutInSec
+		}
+		if *timeoutInSec == 0 {
+			timeoutInSec = nil // back to the configured default
+		}
+		labels := (*Labels)(&target.Labels)
+		if patch.Labels != nil {
+			labels = patch.Labels
+		}
+
+		updated, apiErr := s.newHealthTarget(id, rawURL, timeoutInSec, labels)
+		if apiErr != nil {
+			return apiErr
+		}
+		*target = updated
+		return nil
+	})
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	respondJSON(w, r, http.StatusOK, toTarget(target))
+}
+
+func (s *Server) DeleteTarget(w http.ResponseWriter, r *http.Request, id string) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	key := targetKey(namespace, id)
+	apiErr = s.checker.RemoveTarget(r.Context(), key)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+	s.forgetLimiter("check:" + key)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) GetStatus(w http.ResponseWriter, r *http.Request, params GetStatusParams) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	if params.Fresh != nil && *params.Fresh {
+		if !allow(w, r, s.limiter("fresh:"+namespace, freshStatusInterval)) {
+			return
+		}
+		for _, result := range s.checker.CheckNamespace(r.Context(), namespace) {
+			s.monitor.RecordResult(result)
+		}
+	}
+
+	selector, err := parseLabelSelector(derefOrZero(params.Label))
+	if err != nil {
+		respondError(w, r, ErrInvalidQuery(err.Error(), err))
+		return
+	}
+
+	var statuses []TargetStatus
+	for _, status := range s.monitor.Statuses(namespace) {
+		// Pending targets are neither healthy nor unhealthy
+		if params.Healthy != nil && (status.Pending || status.Result.Healthy != *params.Healthy) {
+			continue
+		}
+		if matchesTarget(status.Target, selector, params.IdPrefix) {
+			statuses = append(statuses, status)
+		}
+	}
+
+	sort := SortID
+	if params.Sort != nil {
+		sort = *params.Sort
+	}
+	var key func(TargetStatus) int64
+	switch sort {
+	case SortID, SortIDDesc:
+		key = func(TargetStatus) int64 { return 0 }
+	case SortLatency, SortLatencyDesc:
+		key = func(status TargetStatus) int64 { return int64(status.Result.Duration) }
+	case SortLastChange, SortLastChangeDesc:
+		key = func(status TargetStatus) int64 { return status.LastChange.UnixNano() }
+	default:
+		respondError(w, r, ErrInvalidQuery(fmt.Sprintf("unknown sort %q", sort), nil))
+		return
+	}
+
+	page := listPage{sort: string(sort), limit: params.Limit, cursor: params.Cursor}
+	statuses, next, err := paginate(statuses, page, key, func(status TargetStatus) string { return status.Target.ID })
+	if err != nil {
+		respondError(w, r, ErrInvalidQuery(err.Error(), err))
+		return
+	}
+
+	results := make([]healthCheckResult, len(statuses))
+	for i, status := range statuses {
+		results[i] = toHealthCheckResult(status)
+	}
+
+	setNextCursor(w, next)
+	respondJSON(w, r, http.StatusOK, results)
+}
+
+// matchesTarget returns true if the target matches the label selector and ID prefix of a listing
+func matchesTarget(target HealthTarget, selector labelSelector, idPrefix *string) bool {
+	if idPrefix != nil && !strings.HasPrefix(target.ID, *idPrefix) {
+		return false
+	}
+	return selector.Matches(target.Labels)
+}
+
+// setNextCursor sets the X-Next-Cursor header if there is another page
+func setNextCursor(w http.ResponseWriter, next string) {
+	if next != "" {
+		w.Header().Set("X-Next-Cursor", next)
+	}
+}
+
+func (s *Server) CheckTarget(w http.ResponseWriter, r *http.Request, id string) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	key := targetKey(namespace, id)
+	if _, ok := s.checker.Target(key); !ok {
+		respondError(w, r, ErrUnknownTarget("", nil))
+		return
+	}
+	if !allow(w, r, s.limiter("check:"+key, targetCheckInterval)) {
+		return
+	}
+
+	result, err := s.checker.CheckTarget(r.Context(), key)
+	if err != nil {
+		respondError(w, r, ErrUnknownTarget("", err))
+		return
+	}
+	s.monitor.RecordResult(result)
+
+	status, ok := s.monitor.Status(key)
+	if !ok {
+		// Removed while being checked
+		respondError(w, r, ErrUnknownTarget("", nil))
+		return
+	}
+	respondJSON(w, r, http.StatusOK, toHealthCheckResult(status))
+}
+
+func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+	selector, err := parseLabelSelector(derefOrZero(params.Label))
+	if err != nil {
+		respondError(w, r, ErrInvalidQuery(err.Error(), err))
+		return
+	}
+	types := derefOrZero(params.Type)
+	for _, t := range types {
+		if t != EventCheck && t != EventState && t != EventIncident {
+			respondError(w, r, ErrInvalidQuery(fmt.Sprintf("unknown event type %q", t), nil))
+			return
+		}
+	}
+	ids := derefOrZero(params.Target)
+	matches := func(target HealthTarget) bool {
+		return target.Namespace == namespace && (len(ids) == 0 || slices.Contains(ids, target.ID)) &&
+			selector.Matches(target.Labels)
+	}
+
+	// Subscribed before the current states are sent, so no change in between is missed
+	sub := s.monitor.Subscribe(func(event Event) bool {
+		return (len(types) == 0 || slices.Contains(types, event.Type)) && matches(event.Status.Target)
+	})
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // disables response buffering of nginx
+	w.WriteHeader(http.StatusOK)
+	rc := http.NewResponseController(w)
+
+	if len(types) == 0 || slices.Contains(types, EventState) {
+		for _, status := range s.monitor.Statuses(namespace) {
+			if !status.Pending && matches(status.Target) {
+				writeEvent(w, Event{Type: EventState, Status: status})
+			}
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		slog.Error("failed to stream events", "error", err)
+		return
+	}
+
+	keepAlive := time.NewTicker(eventKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case event, ok := <-sub.Events:
+			if !ok {
+				// Fell behind or shutting down, the client reconnects and gets the current states again
+				return
+			}
+			writeEvent(w, event)
+		case <-keepAlive.C:
+			_, _ = io.WriteString(w, ": keep-alive\n\n")
+		case <-r.Context().Done():
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// eventData is the data of a server-sent event
+type eventData struct {
+	healthCheckResult
+	Incident string `json:"incident,omitempty"`
+}
+
+func writeEvent(w io.Writer, event Event) {
+	data, err := json.Marshal(eventData{healthCheckResult: toHealthCheckResult(event.Status), Incident: event.Incident})
+	if err != nil {
+		slog.Error("failed to encode event", "error", err)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+}
+
+func (s *Server) GetTargetHistory(w http.ResponseWriter, r *http.Request, id string) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	key := targetKey(namespace, id)
+	if _, ok := s.checker.Target(key); !ok {
+		respondError(w, r, ErrUnknownTarget("", nil))
+		return
+	}
+
+	history := s.monitor.History(key)
+	entries := make([]HistoryEntry, len(history))
+	for i, result := range history {
+		entries[i] = HistoryEntry{
 			Timestamp:       result.Timestamp,
+			Healthy:         result.Healthy,
+			Status:          result.Status,
 			DurationSeconds: result.Duration.Seconds(),
 		}
-
 		if result.Error != nil {
 			errStr := result.Error.Error()
-			jsonResult.Error = &errStr
+			entries[i].Error = &errStr
+		}
+	}
+	respondJSON(w, r, http.StatusOK, entries)
+}
+
+func (s *Server) ListIncidents(w http.ResponseWriter, r *http.Request, params ListIncidentsParams) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	incidents := []Incident{}
+	for _, incident := range s.monitor.Incidents(namespace) {
+		if params.Target != nil && incident.Target.ID != *params.Target {
+			continue
+		}
+		if params.Open != nil && incident.ResolvedAt.IsZero() != *params.Open {
+			continue
+		}
+		incidents = append(incidents, toIncident(incident))
+	}
+	respondJSON(w, r, http.StatusOK, incidents)
+}
+
+func (s *Server) ListSilences(w http.ResponseWriter, r *http.Request) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	silences := []Silence{}
+	for _, rule := range s.monitor.Silences().List(namespace) {
+		silences = append(silences, toSilence(rule))
+	}
+	respondJSON(w, r, http.StatusOK, silences)
+}
+
+func (s *Server) CreateSilence(w http.ResponseWriter, r *http.Request) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	var silence NewSilence
+	if err := json.NewDecoder(r.Body).Decode(&silence); err != nil {
+		respondError(w, r, ErrParseJsonBody(err.Error(), err))
+		return
+	}
+	rule := SilenceRule{
+		Namespace: namespace,
+		Targets:   derefOrZero(silence.Targets),
+		Labels:    derefOrZero(silence.Label),
+		Comment:   strings.TrimSpace(silence.Comment),
+		StartsAt:  time.Now(),
+		EndsAt:    silence.EndsAt,
+	}
+	if silence.StartsAt != nil {
+		rule.StartsAt = *silence.StartsAt
+	}
+	if principal, ok := PrincipalFromContext(r.Context()); ok {
+		rule.CreatedBy = principal.Name
+	}
+	if rule.Comment == "" {
+		respondError(w, r, ErrInvalidSilence("comment must not be empty", nil))
+		return
+	}
+	if !rule.EndsAt.After(rule.StartsAt) || !rule.EndsAt.After(time.Now()) {
+		respondError(w, r, ErrInvalidSilence("endsAt must be after startsAt and in the future", nil))
+		return
+	}
+
+	rule, err := s.monitor.Silences().Add(rule)
+	if err != nil {
+		respondError(w, r, ErrInvalidSilence(err.Error(), err))
+		return
+	}
+	respondJSON(w, r, http.StatusCreated, toSilence(rule))
+}
+
+func (s *Server) DeleteSilence(w http.ResponseWriter, r *http.Request, id int64) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	if !s.monitor.Silences().Remove(namespace, id) {
+		respondError(w, r, ErrUnknownSilence("", nil))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// statusPageAccess checks that a status page is configured and the client isn't bound
+// to a namespace, the status page spans all of them
+func (s *Server) statusPageAccess(r *http.Request) *ApiError {
+	if s.statusPage == nil {
+		return ErrNoStatusPage("", nil)
+	}
+	if principal, ok := PrincipalFromContext(r.Context()); ok && principal.Namespace != "" {
+		return ErrForbidden(fmt.Sprintf("%s is bound to the %s namespace and can't access the status page", principal.Name, principal.Namespace), nil)
+	}
+	return nil
+}
+
+func (s *Server) ListStatusPageIncidents(w http.ResponseWriter, r *http.Request) {
+	if apiErr := s.statusPageAccess(r); apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	incidents := s.statusPage.Incidents()
+	result := make([]StatusPageIncident, len(incidents))
+	for i, incident := range incidents {
+		result[i] = toStatusPageIncident(incident)
+	}
+	respondJSON(w, r, http.StatusOK, result)
+}
+
+func (s *Server) AddStatusPageUpdate(w http.ResponseWriter, r *http.Request, id int64) {
+	if apiErr := s.statusPageAccess(r); apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	var body NewStatusPageUpdate
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondError(w, r, ErrParseJsonBody(err.Error(), err))
+		return
+	}
+	update := StatusUpdate{Message: strings.TrimSpace(body.Message), At: time.Now()}
+	if update.Message == "" {
+		respondError(w, r, ErrInvalidStatusPageUpdate("message must not be empty", nil))
+		return
+	}
+	if principal, ok := PrincipalFromContext(r.Context()); ok {
+		update.Author = principal.Name
+	}
+
+	incident, ok := s.statusPage.AddUpdate(id, update)
+	if !ok {
+		respondError(w, r, ErrUnknownStatusPageIncident("", nil))
+		return
+	}
+	respondJSON(w, r, http.StatusCreated, toStatusPageIncident(incident))
+}
+
+func (s *Server) GetFailedNotifications(w http.ResponseWriter, r *http.Request) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
+	}
+
+	deadLetters := slices.DeleteFunc(s.notifications.DeadLetters(), func(n Notification) bool {
+		return n.Target.Namespace != namespace
+	})
+
+	failed := make([]FailedNotification, len(deadLetters))
+	for i, n := range deadLetters {
+		failed[i] = FailedNotification{
+			Id:        n.ID,
+			Channel:   n.Channel,
+			Kind:      FailedNotificationKind(n.Kind),
+			TargetId:  n.Target.ID,
+			Url:       n.Target.URLString,
+			Attempts:  n.Attempts,
+			CreatedAt: n.CreatedAt,
+			FailedAt:  n.FailedAt,
+		}
+		if n.LastError != "" {
+			lastError := n.LastError
+			failed[i].LastError = &lastError
 		}
+	}
+
+	respondJSON(w, r, http.StatusOK, failed)
+}
 
-		jsonResults[i] = jsonResult
+func (s *Server) TestNotifications(w http.ResponseWriter, r *http.Request, params TestNotificationsParams) {
+	namespace, apiErr := requestNamespace(r)
+	if apiErr != nil {
+		respondError(w, r, apiErr)
+		return
 	}
 
-	respondJSON(w, r, http.StatusOK, jsonResults)
+	channels := slices.DeleteFunc(s.notifications.Channels(), func(c NotificationChannel) bool {
+		return c.Namespace != namespace
+	})
+	if params.Channel != nil {
+		name := channelName(namespace, *params.Channel)
+		idx := slices.IndexFunc(channels, func(c NotificationChannel) bool { return c.Name == name })
+		if idx < 0 {
+			respondError(w, r, ErrUnknownChannel(fmt.Sprintf("channel %q is not configured", *params.Channel), nil))
+			return
+		}
+		channels = channels[idx : idx+1]
+	}
+
+	testResults := SendTestNotifications(channels)
+
+	results := make([]NotificationTestResult, len(testResults))
+	for i, result := range testResults {
+		results[i] = NotificationTestResult{
+			Channel: result.Channel,
+			Kind:    NotificationTestResultKind(result.Kind),
+			Success: result.Error == nil,
+		}
+		if result.Error != nil {
+			errStr := result.Error.Error()
+			results[i].Error = &errStr
+		}
+	}
+
+	respondJSON(w, r, http.StatusOK, results)
 }
 
 func respondError(w http.ResponseWriter, r *http.Request, error *ApiError) {
-	slog.Error("unhandled error", "method", r.Method, "url", r.URL, "error", error.Error, "origin", error.Origin)
+	slog.Error("unhandled error", "method", r.Method, "url", r.URL, "error", error.Error(), "origin", error.Origin)
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(error.Status)
 	_ = json.NewEncoder(w).Encode(Error{Code: error.Code, Message: error.Message})
 }
 
 func respondJSON(w http.ResponseWriter, r *http.Request, status int, data any) {
-	if err := json.NewEncoder(w).Encode(data); err != nil {
+	// Encode first, the status can't be changed once the body is written
+	body, err := json.Marshal(data)
+	if err != nil {
 		respondError(w, r, ErrEncodeJsonBody("", err))
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
+	_, _ = w.Write(append(body, '\n'))
+}
+
+// limiter returns the rate limiter with the given name, creating it on first use
+func (s *Server) limiter(name string, interval time.Duration) *rate.Limiter {
+	s.limitersMu.Lock()
+	defer s.limitersMu.Unlock()
+	limiter, ok := s.limiters[name]
+	if !ok {
+		// Targets also disappear through reloads and other replicas, so idle limiters
+		// are dropped too. They allow a request just like a new one would.
+		now := time.Now()
+		for other, l := range s.limiters {
+			if l.TokensAt(now) >= float64(l.Burst()) {
+				delete(s.limiters, other)
+			}
+		}
+		limiter = rate.NewLimiter(rate.Every(interval), 1)
+		s.limiters[name] = limiter
+	}
+	return limiter
+}
+
+// forgetLimiter drops the rate limiter with the given name
+func (s *Server) forgetLimiter(name string) {
+	s.limitersMu.Lock()
+	defer s.limitersMu.Unlock()
+	delete(s.limiters, name)
+}
+
+// allow responds with 429 Too Many Requests if limiter doesn't allow another request
+func allow(w http.ResponseWriter, r *http.Request, limiter *rate.Limiter) bool {
+	reservation := limiter.Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return true
+	}
+	reservation.Cancel()
+
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+	respondError(w, r, ErrTooManyRequests("", nil))
+	return false
+}
+
+// healthCheckResult is the API representation of a TargetStatus
+type healthCheckResult struct {
+	ID                  string            `json:"id"`
+	Namespace           string            `json:"namespace,omitempty"`
+	URL                 string            `json:"url"`
+	Status              int               `json:"status"`
+	Healthy             bool              `json:"healthy"`
+	Pending             bool              `json:"pending,omitempty"`
+	Timestamp           time.Time         `json:"timestamp"`
+	DurationSeconds     float64           `json:"duration_seconds"`
+	Error               *string           `json:"error,omitempty"`
+	Labels              map[string]string `json:"labels,omitempty"`
+	Source              string            `json:"source,omitempty"`
+	ConsecutiveFailures int               `json:"consecutive_failures"`
+	Alerted             bool              `json:"alerted"`
+	LastChange          time.Time         `json:"last_change"`
+}
+
+func toHealthCheckResult(status TargetStatus) healthCheckResult {
+	result := healthCheckResult{
+		ID:                  status.Target.ID,
+		Namespace:           status.Target.Namespace,
+		URL:                 status.Target.URLString,
+		Status:              status.Result.Status,
+		Healthy:             status.Result.Healthy,
+		Pending:             status.Pending,
+		Timestamp:           status.Result.Timestamp,
+		DurationSeconds:     status.Result.Duration.Seconds(),
+		Labels:              status.Target.Labels,
+		Source:              status.Target.Source,
+		ConsecutiveFailures: status.ConsecutiveFailures,
+		Alerted:             status.Alerted,
+		LastChange:          status.LastChange,
+	}
+	if status.Result.Error != nil {
+		errStr := status.Result.Error.Error()
+		result.Error = &errStr
+	}
+	return result
+}
+
+func toIncident(incident TargetIncident) Incident {
+	result := Incident{
+		Id:       incident.ID,
+		TargetId: incident.Target.ID,
+		Url:      incident.Target.URLString,
+		OpenedAt: incident.OpenedAt,
+		Silenced: incident.Silenced,
+		Removed:  incident.Removed,
+	}
+	if incident.Target.Namespace != "" {
+		result.Namespace = &incident.Target.Namespace
+	}
+	if !incident.ResolvedAt.IsZero() {
+		result.ResolvedAt = &incident.ResolvedAt
+	}
+	if incident.Error != "" {
+		result.Error = &incident.Error
+	}
+	return result
+}
+
+func toSilence(rule SilenceRule) Silence {
+	silence := Silence{
+		Id:        rule.ID,
+		Comment:   rule.Comment,
+		CreatedBy: rule.CreatedBy,
+		StartsAt:  rule.StartsAt,
+		EndsAt:    rule.EndsAt,
+	}
+	if rule.Namespace != "" {
+		silence.Namespace = &rule.Namespace
+	}
+	if len(rule.Targets) > 0 {
+		silence.Targets = &rule.Targets
+	}
+	if len(rule.Labels) > 0 {
+		silence.Label = &rule.Labels
+	}
+	return silence
+}
+
+func toStatusPageIncident(incident StatusIncident) StatusPageIncident {
+	result := StatusPageIncident{
+		Id:         incident.ID,
+		Component:  incident.Component,
+		Title:      incident.Title,
+		StartedAt:  incident.StartedAt,
+		ResolvedAt: incident.ResolvedAt,
+		Updates:    make([]StatusPageUpdate, len(incident.Updates)),
+	}
+	for i, update := range incident.Updates {
+		result.Updates[i] = StatusPageUpdate{Message: update.Message, At: update.At}
+		if update.Author != "" {
+			result.Updates[i].Author = &update.Author
+		}
+	}
+	return result
+}
+
+// newHealthTarget validates the fields of a target received through the API
+func (s *Server) newHealthTarget(id, rawURL string, timeoutInSec *int, labels *Labels) (HealthTarget, *ApiError) {
+	if err := validateTargetID(id); err != nil {
+		return HealthTarget{}, ErrInvalidTarget(err.Error(), err)
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return HealthTarget{}, ErrInvalidUrl("", err)
+	}
+	if err := validateTargetURL(parsedURL); err != nil {
+		return HealthTarget{}, ErrInvalidUrl(err.Error(), err)
+	}
+	if err := s.checker.CheckEgress(parsedURL); err != nil {
+		return HealthTarget{}, ErrUrlNotAllowed(err.Error(), err)
+	}
+
+	target := HealthTarget{
+		URL:       parsedURL,
+		URLString: rawURL,
+		ID:        id,
+	}
+	if timeoutInSec != nil {
+		if *timeoutInSec < 1 {
+			return HealthTarget{}, ErrInvalidTarget("timeoutInSec must be at least 1", nil)
+		}
+		target.TimeoutInSec = *timeoutInSec
+	}
+	if labels != nil && len(*labels) > 0 {
+		if err := validateLabels(*labels); err != nil {
+			return HealthTarget{}, ErrInvalidTarget(err.Error(), err)
+		}
+		target.Labels = maps.Clone(*labels)
+	}
+	return target, nil
+}
+
+// toTarget converts a target to its API representation
+func toTarget(target HealthTarget) Target {
+	result := Target{
+		Id:  target.ID,
+		Url: target.URLString,
+	}
+	if target.TimeoutInSec > 0 {
+		timeoutInSec := target.TimeoutInSec
+		result.TimeoutInSec = &timeoutInSec
+	}
+	if len(target.Labels) > 0 {
+		labels := Labels(maps.Clone(target.Labels))
+		result.Labels = &labels
+	}
+	if target.Namespace != "" {
+		namespace := target.Namespace
+		result.Namespace = &namespace
+	}
+	if target.Source != "" {
+		source := TargetSource(target.Source)
+		result.Source = &source
+	}
+	return result
 }
@@ -0,0 +1,460 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *HealthChecker) {
+	t.Helper()
+	checker, err := NewHealthChecker(time.Second, nil)
+	if err != nil {
+		t.Fatalf("Failed to create health checker: %v", err)
+	}
+	router := http.NewServeMux()
+	monitor := NewHealthMonitor(checker, time.Hour, nil)
+	HandlerFromMux(NewServer(checker, monitor, nil), router)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server, checker
+}
+
+func doRequest(t *testing.T, method, url, body string) (*http.Response, string) {
+	t.Helper()
+	return doRequestWithType(t, method, url, "application/json", body)
+}
+
+func doRequestWithType(t *testing.T, method, url, contentType, body string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp, string(data)
+}
+
+func TestTargetsAPI(t *testing.T) {
+	server, checker := newTestServer(t)
+
+	steps := []struct {
+		method, path, body string
+		status             int
+		response           string
+	}{
+		{"GET", "/targets/example", "", http.StatusNotFound, `"target_not_found"`},
+		{"POST", "/targets", `{"id": "example", "url": "https://example.com"}`, http.StatusCreated, `"url":"https://example.com"`},
+		{"POST", "/targets", `{"id": "example", "url": "https://example.com"}`, http.StatusConflict, `"target_exists"`},
+		{"POST", "/register", `{"id": "example", "url": "https://example.com"}`, http.StatusConflict, `"target_exists"`},
+		{"POST", "/targets", `{"id": "other", "url": "not a url"}`, http.StatusBadRequest, `"invalid_url"`},
+		{"POST", "/targets", `{"id": "other", "url": "file:///etc/passwd"}`, http.StatusBadRequest, `"invalid_url"`},
+		{"POST", "/targets", `{"id": "other", "url": "http://169.254.169.254/latest/meta-data"}`, http.StatusBadRequest, `"url_not_allowed"`},
+		{"POST", "/targets", `{"id": "", "url": "https://example.com"}`, http.StatusBadRequest, `"invalid_target"`},
+		{"POST", "/targets", `{"id": "../admin", "url": "https://example.com"}`, http.StatusBadRequest, `"invalid_target"`},
+		{"PATCH", "/targets/example", `{"timeoutInSec": 5}`, http.StatusOK, `"timeoutInSec":5`},
+		{"PUT", "/targets/example", `{"url": "https://example.com/health"}`, http.StatusOK, `"url":"https://example.com/health"`},
+		{"PUT", "/targets/other", `{"url": "https://other.example.com"}`, http.StatusCreated, `"id":"other"`},
+		{"GET", "/targets", "", http.StatusOK, `[{"id":"example","source":"api","url":"https://example.com/health"},{"id":"other","source":"api","url":"https://other.example.com"}]`},
+		{"DELETE", "/targets/other", "", http.StatusNoContent, ""},
+		{"DELETE", "/targets/other", "", http.StatusNotFound, `"target_not_found"`},
+		{"DELETE", "/unregister/other", "", http.StatusNotFound, `"target_not_found"`},
+		{"PATCH", "/targets/other", `{"timeoutInSec": 5}`, http.StatusNotFound, `"target_not_found"`},
+	}
+	for _, step := range steps {
+		resp, body := doRequest(t, step.method, server.URL+step.path, step.body)
+		if resp.StatusCode != step.status || !strings.Contains(body, step.response) {
+			t.Fatalf("%s %s: expected %d with %s, got %d: %s", step.method, step.path, step.status, step.response, resp.StatusCode, body)
+		}
+	}
+
+	if len(checker.Targets()) != 1 {
+		t.Fatalf("Expected one target to be left, got %v", checker.Targets())
+	}
+
+	resp, body := doRequest(t, "GET", server.URL+"/targets/example", "")
+	var target Target
+	if err := json.Unmarshal([]byte(body), &target); err != nil || resp.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("Expected a JSON target, got %s (%v)", body, err)
+	}
+	if target.TimeoutInSec != nil {
+		t.Fatalf("Expected PUT to replace the whole target, got timeout %d", *target.TimeoutInSec)
+	}
+}
+
+func TestBulkImport(t *testing.T) {
+	server, checker := newTestServer(t)
+	doRequest(t, "POST", server.URL+"/targets", `{"id": "old", "url": "https://old.example.com"}`)
+	doRequest(t, "POST", server.URL+"/targets", `{"id": "same", "url": "https://same.example.com"}`)
+
+	csv := "id,url,timeoutInSec\nsame,https://same.example.com,\nnew,https://new.example.com,5\n"
+	steps := []struct {
+		query, contentType, body string
+		status                   int
+		response                 string
+	}{
+		{"?mode=replace&dryRun=true", "text/csv", csv, http.StatusOK, `{"created":["new"],"deleted":["old"],"dryRun":true,"unchanged":["same"],"updated":[]}`},
+		{"", "text/csv", "id,url\nbroken,ftp://example.com\n", http.StatusBadRequest, `target 1: url must be`},
+		{"", "text/plain", csv, http.StatusUnsupportedMediaType, `"unsupported_media_type"`},
+		{"?mode=replace", "application/yaml", "- id: same\n  url: https://same.example.com\n  timeoutInSec: 3\n", http.StatusOK, `{"created":[],"deleted":["old"],"dryRun":false,"unchanged":[],"updated":["same"]}`},
+	}
+	for _, step := range steps {
+		resp, body := doRequestWithType(t, "POST", server.URL+"/targets:bulk"+step.query, step.contentType, step.body)
+		if resp.StatusCode != step.status || !strings.Contains(body, step.response) {
+			t.Fatalf("%s: expected %d with %s, got %d: %s", step.query, step.status, step.response, resp.StatusCode, body)
+		}
+	}
+
+	targets := checker.Targets()
+	if len(targets) != 1 || targets[0].ID != "same" || targets[0].TimeoutInSec != 3 {
+		t.Fatalf("Unexpected targets after import: %+v", targets)
+	}
+
+	resp, body := doRequest(t, "GET", server.URL+"/targets:export?format=csv", "")
+	if resp.Header.Get("Content-Type") != "text/csv" || body != "id,url,timeoutInSec,labels\nsame,https://same.example.com,3,\n" {
+		t.Fatalf("Unexpected export: %s", body)
+	}
+}
+
+func TestStatusAPI(t *testing.T) {
+	server, _ := newTestServer(t)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(target.Close)
+	doRequest(t, "POST", server.URL+"/targets", `{"id": "example", "url": "`+target.URL+`"}`)
+
+	steps := []struct {
+		method, path string
+		status       int
+		response     string
+	}{
+		{"GET", "/status", http.StatusOK, `"pending":true`}, // not checked yet
+		{"GET", "/status?healthy=false", http.StatusOK, `[]`},
+		{"GET", "/status?fresh=true", http.StatusOK, `"healthy":true`},
+		{"GET", "/status?fresh=true", http.StatusTooManyRequests, `"too_many_requests"`},
+		{"GET", "/status", http.StatusOK, `"consecutive_failures":0`},
+		{"POST", "/targets/example/check", http.StatusOK, `"id":"example"`},
+		{"POST", "/targets/example/check", http.StatusTooManyRequests, `"too_many_requests"`},
+		{"POST", "/targets/missing/check", http.StatusNotFound, `"target_not_found"`},
+	}
+	for _, step := range steps {
+		resp, body := doRequest(t, step.method, server.URL+step.path, "")
+		if resp.StatusCode != step.status || !strings.Contains(body, step.response) {
+			t.Fatalf("%s %s: expected %d with %s, got %d: %s", step.method, step.path, step.status, step.response, resp.StatusCode, body)
+		}
+		if resp.StatusCode == http.StatusTooManyRequests && resp.Header.Get("Retry-After") == "" {
+			t.Fatalf("%s %s: expected a Retry-After header", step.method, step.path)
+		}
+	}
+}
+
+func TestRateLimitersOfRemovedTargets(t *testing.T) {
+	checker, err := NewHealthChecker(time.Second, nil)
+	if err != nil {
+		t.Fatalf("Failed to create health checker: %v", err)
+	}
+	api := NewServer(checker, NewHealthMonitor(checker, time.Hour, nil), nil)
+	router := http.NewServeMux()
+	HandlerFromMux(api, router)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(target.Close)
+
+	for _, id := range []string{"first", "second"} {
+		doRequest(t, "POST", server.URL+"/targets", `{"id": "`+id+`", "url": "`+target.URL+`"}`)
+		if resp, body := doRequest(t, "POST", server.URL+"/targets/"+id+"/check", ""); resp.StatusCode != http.StatusOK {
+			t.Fatalf("Failed to check %s: %s", id, body)
+		}
+	}
+	if resp, _ := doRequest(t, "DELETE", server.URL+"/targets/first", ""); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("Failed to delete target: %d", resp.StatusCode)
+	}
+
+	api.limitersMu.Lock()
+	defer api.limitersMu.Unlock()
+	if _, ok := api.limiters["check:first"]; ok || len(api.limiters) != 1 {
+		t.Fatalf("Expected only the limiter of the remaining target, got %v", api.limiters)
+	}
+}
+
+func TestStatusQueries(t *testing.T) {
+	checker, err := NewHealthChecker(time.Second, nil)
+	if err != nil {
+		t.Fatalf("Failed to create health checker: %v", err)
+	}
+	monitor := NewHealthMonitor(checker, time.Hour, nil)
+	router := http.NewServeMux()
+	HandlerFromMux(NewServer(checker, monitor, nil), router)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	now := time.Now()
+	for i, spec := range []struct {
+		id      string
+		labels  map[string]string
+		healthy bool
+		latency time.Duration
+	}{
+		{"api-1", map[string]string{"env": "prod"}, false, 300 * time.Millisecond},
+		{"api-2", map[string]string{"env": "prod"}, true, 100 * time.Millisecond},
+		{"db-1", map[string]string{"env": "staging"}, false, 200 * time.Millisecond},
+		{"db-2", nil, false, 200 * time.Millisecond},
+	} {
+		target := mustTarget(t, spec.id, "https://"+spec.id+".example.com")
+		target.Labels = spec.labels
+		if apiErr := checker.AddTarget(context.Background(), target); apiErr != nil {
+			t.Fatalf("Failed to add target: %v", apiErr)
+		}
+		target, _ = checker.Target(spec.id)
+		monitor.RecordResult(Result{Target: target, Healthy: spec.healthy, Duration: spec.latency, Timestamp: now.Add(time.Duration(i) * time.Second)})
+	}
+
+	ids := func(body string) string {
+		var results []healthCheckResult
+		if err := json.Unmarshal([]byte(body), &results); err != nil {
+			t.Fatalf("Failed to decode results: %v", err)
+		}
+		var ids []string
+		for _, result := range results {
+			ids = append(ids, result.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"", "api-1,api-2,db-1,db-2"},
+		{"?healthy=false", "api-1,db-1,db-2"},
+		{"?healthy=false&label=env=prod", "api-1"},
+		{"?label=env!=prod", "db-1,db-2"},
+		{"?label=env&label=!team", "api-1,api-2,db-1"},
+		{"?idPrefix=db-", "db-1,db-2"},
+		{"?sort=-latency", "api-1,db-2,db-1,api-2"},
+		{"?sort=lastChange", "api-1,api-2,db-1,db-2"},
+		{"?sort=-id", "db-2,db-1,api-2,api-1"},
+	}
+	for _, test := range tests {
+		resp, body := doRequest(t, "GET", server.URL+"/status"+test.query, "")
+		if resp.StatusCode != http.StatusOK || ids(body) != test.expected {
+			t.Errorf("%s: expected %s, got %d: %s", test.query, test.expected, resp.StatusCode, body)
+		}
+	}
+
+	var pages []string
+	query := "?sort=latency&limit=3"
+	for {
+		resp, body := doRequest(t, "GET", server.URL+"/status"+query, "")
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected response: %d: %s", resp.StatusCode, body)
+		}
+		pages = append(pages, ids(body))
+		next := resp.Header.Get("X-Next-Cursor")
+		if next == "" {
+			break
+		}
+		query = "?sort=latency&limit=3&cursor=" + next
+	}
+	if strings.Join(pages, "|") != "api-2,db-1,db-2|api-1" {
+		t.Fatalf("Unexpected pages: %v", pages)
+	}
+
+	for _, query := range []string{"?limit=0", "?cursor=garbage", "?label=bad%20key", "?sort=id&limit=1&cursor=" + listCursor{Sort: "latency"}.encode()} {
+		resp, body := doRequest(t, "GET", server.URL+"/status"+query, "")
+		if resp.StatusCode != http.StatusBadRequest || !strings.Contains(body, `"invalid_query"`) {
+			t.Errorf("%s: expected 400, got %d: %s", query, resp.StatusCode, body)
+		}
+	}
+}
+
+func TestNamespaces(t *testing.T) {
+	auth := mustAuthenticator(t, &AuthConfig{APIKeys: []APIKeyConfig{
+		{Name: "payments", Key: "payments-key-0123456789", Role: RoleOperator, Namespace: "payments"},
+		{Name: "search", Key: "search-key-0123456789", Role: RoleOperator, Namespace: "search"},
+		{Name: "ops", Key: "ops-key-0123456789", Role: RoleAdmin},
+	}})
+	checker, _ := NewHealthChecker(time.Second, nil)
+	router := http.NewServeMux()
+	HandlerWithOptions(NewServer(checker, NewHealthMonitor(checker, time.Hour, nil), nil), StdHTTPServerOptions{
+		BaseRouter:  router,
+		Middlewares: []MiddlewareFunc{auth.Middleware},
+	})
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	request := func(method, path, key, namespace, body string) (int, string) {
+		t.Helper()
+		req, _ := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		req.Header.Set("X-API-Key", key)
+		if namespace != "" {
+			req.Header.Set("X-Namespace", namespace)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(data)
+	}
+
+	// The same ID in two namespaces doesn't collide
+	for _, key := range []string{"payments-key-0123456789", "search-key-0123456789"} {
+		if status, body := request("POST", "/targets", key, "", `{"id": "api", "url": "https://api.example.com"}`); status != http.StatusCreated {
+			t.Fatalf("Expected 201, got %d: %s", status, body)
+		}
+	}
+
+	steps := []struct {
+		method, path, key, namespace string
+		status                       int
+		response                     string
+	}{
+		{"GET", "/targets", "payments-key-0123456789", "", http.StatusOK, `[{"id":"api","namespace":"payments","source":"api","url":"https://api.example.com"}]`},
+		{"GET", "/targets", "payments-key-0123456789", "search", http.StatusForbidden, `"forbidden"`},
+		{"DELETE", "/targets/api", "search-key-0123456789", "", http.StatusNoContent, ``},
+		{"GET", "/targets/api", "payments-key-0123456789", "", http.StatusOK, `"namespace":"payments"`},
+		{"GET", "/targets", "ops-key-0123456789", "", http.StatusOK, `[]`},
+		{"GET", "/targets/api", "ops-key-0123456789", "payments", http.StatusOK, `"namespace":"payments"`},
+		{"GET", "/targets", "ops-key-0123456789", "Not_Valid", http.StatusBadRequest, `"invalid_namespace"`},
+		{"POST", "/targets", "ops-key-0123456789", "", http.StatusBadRequest, `"invalid_target"`},
+	}
+	for _, step := range steps {
+		body := ""
+		if step.method == "POST" {
+			body = `{"id": "payments/api", "url": "https://api.example.com"}`
+		}
+		status, response := request(step.method, step.path, step.key, step.namespace, body)
+		if status != step.status || !strings.Contains(response, step.response) {
+			t.Fatalf("%s %s as %s: expected %d with %s, got %d: %s", step.method, step.path, step.key, step.status, step.response, status, response)
+		}
+	}
+}
+
+func TestIncidentsAndSilences(t *testing.T) {
+	checker, _ := NewHealthChecker(time.Second, nil)
+	var alerts atomic.Int32
+	notifications, _ := NewNotificationQueue([]NotificationChannel{{Name: "count", Alert: func(HealthTarget, Result) error {
+		alerts.Add(1)
+		return nil
+	}}}, NotificationConfig{})
+	notifications.Start()
+	defer notifications.Stop(context.Background())
+	monitor := NewHealthMonitor(checker, time.Hour, notifications)
+	router := http.NewServeMux()
+	HandlerFromMux(NewServer(checker, monitor, notifications), router)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	if err := checker.AddTarget(context.Background(), testResult(true).Target); err != nil {
+		t.Fatalf("Failed to add target: %v", err)
+	}
+
+	endsAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	steps := []struct {
+		method, path, body string
+		status             int
+		response           string
+	}{
+		{"POST", "/silences", `{"comment": "", "endsAt": "` + endsAt + `"}`, http.StatusBadRequest, `"invalid_silence"`},
+		{"POST", "/silences", `{"comment": "deploy", "endsAt": "2000-01-01T00:00:00Z"}`, http.StatusBadRequest, `"invalid_silence"`},
+		{"POST", "/silences", `{"targets": ["example"], "comment": "deploy", "endsAt": "` + endsAt + `"}`, http.StatusCreated, `"id":1`},
+		{"GET", "/silences", "", http.StatusOK, `"comment":"deploy"`},
+	}
+	for _, step := range steps {
+		resp, body := doRequest(t, step.method, server.URL+step.path, step.body)
+		if resp.StatusCode != step.status || !strings.Contains(body, step.response) {
+			t.Fatalf("%s %s: expected %d with %s, got %d: %s", step.method, step.path, step.status, step.response, resp.StatusCode, body)
+		}
+	}
+
+	// Silenced incidents are recorded without an alert
+	for _, healthy := range []bool{false, false, true} {
+		monitor.processResult(testResult(healthy))
+	}
+	if _, body := doRequest(t, "GET", server.URL+"/incidents", ""); !strings.Contains(body, `"silenced":true`) || !strings.Contains(body, `"resolved_at"`) {
+		t.Fatalf("Expected a resolved silenced incident, got %s", body)
+	}
+	if _, body := doRequest(t, "GET", server.URL+"/targets/example/history", ""); strings.Count(body, `"timestamp"`) != 3 {
+		t.Fatalf("Expected 3 history entries, got %s", body)
+	}
+
+	if resp, _ := doRequest(t, "DELETE", server.URL+"/silences/1", ""); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d", resp.StatusCode)
+	}
+	if resp, _ := doRequest(t, "DELETE", server.URL+"/silences/1", ""); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected 404, got %d", resp.StatusCode)
+	}
+	monitor.processResult(testResult(false))
+	monitor.processResult(testResult(false))
+	waitFor(t, time.Second, func() bool { return alerts.Load() == 1 })
+	if _, body := doRequest(t, "GET", server.URL+"/incidents?open=true", ""); strings.Count(body, `"id"`) != 1 || strings.Contains(body, `"silenced":true`) {
+		t.Fatalf("Expected one open alerted incident, got %s", body)
+	}
+
+	// Removing the target closes its incident as removed
+	if resp, _ := doRequest(t, "DELETE", server.URL+"/targets/example", ""); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d", resp.StatusCode)
+	}
+	monitor.pruneStates()
+	if _, body := doRequest(t, "GET", server.URL+"/incidents?open=true", ""); strings.Contains(body, `"id"`) {
+		t.Fatalf("Expected no open incidents, got %s", body)
+	}
+	if incidents := monitor.Incidents(""); !incidents[0].Removed || incidents[0].ResolvedAt.IsZero() || incidents[1].Removed {
+		t.Fatalf("Expected only the latest incident to be closed as removed, got %+v", incidents)
+	}
+}
+
+func TestDashboard(t *testing.T) {
+	server := httptest.NewServer(DashboardHandler())
+	t.Cleanup(server.Close)
+
+	for path, content := range map[string]string{"/ui/": "<title>Doctor</title>", "/ui/app.js": "streamEvents"} {
+		resp, body := doRequest(t, "GET", server.URL+path, "")
+		if resp.StatusCode != http.StatusOK || !strings.Contains(body, content) {
+			t.Fatalf("GET %s: expected %s, got %d", path, content, resp.StatusCode)
+		}
+	}
+}
+
+func TestNotificationTestAPI(t *testing.T) {
+	smtpServer := newFakeSMTPServer(t, "", "")
+	smtp := smtpServer.config()
+	smtp.AuthType = AuthTypeNone
+	notifications, _ := NewNotificationQueue([]NotificationChannel{
+		{Name: "email", Alert: NewEmailAlert(smtp), Resolve: NewEmailResolve(smtp)},
+		{Name: "pager", Alert: func(HealthTarget, Result) error { return errors.New("pager is down") }},
+		{Name: "team-a/email", Namespace: "team-a", Alert: NewEmailAlert(smtp)},
+	}, NotificationConfig{})
+	checker, _ := NewHealthChecker(time.Second, nil)
+	router := http.NewServeMux()
+	HandlerFromMux(NewServer(checker, NewHealthMonitor(checker, time.Hour, notifications), notifications), router)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	resp, body := doRequest(t, http.MethodPost, server.URL+"/notifications/test?channel=email", "")
+	if resp.StatusCode != http.StatusOK || strings.Count(body, `"success":true`) != 2 || strings.Contains(body, "pager") {
+		t.Fatalf("Expected only the email channel to be tested, got %d: %s", resp.StatusCode, body)
+	}
+	resp, body = doRequest(t, http.MethodPost, server.URL+"/notifications/test", "")
+	if resp.StatusCode != http.StatusOK || !strings.Contains(body, `"error":"pager is down","kind":"alert","success":false`) || strings.Contains(body, "team-a") {
+		t.Fatalf("Expected the pager to fail and other namespaces to be left out, got %d: %s", resp.StatusCode, body)
+	}
+	resp, body = doRequest(t, http.MethodPost, server.URL+"/notifications/test?channel=sms", "")
+	if resp.StatusCode != http.StatusNotFound || !strings.Contains(body, "unknown_channel") {
+		t.Fatalf("Expected 404 for an unknown channel, got %d: %s", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// SilenceRule suppresses the alerts of matching targets between StartsAt and EndsAt
+type SilenceRule struct {
+	ID        int64
+	Namespace string
+	Targets   []string // target IDs, all targets matching Labels if empty
+	Labels    []string // label selectors
+	Comment   string
+	CreatedBy string
+	StartsAt  time.Time
+	EndsAt    time.Time
+
+	selector labelSelector
+}
+
+// Matches reports whether the rule silences target at the given time
+func (s SilenceRule) Matches(target HealthTarget, now time.Time) bool {
+	if now.Before(s.StartsAt) || !now.Before(s.EndsAt) || target.Namespace != s.Namespace {
+		return false
+	}
+	if len(s.Targets) > 0 && !slices.Contains(s.Targets, target.ID) {
+		return false
+	}
+	return s.selector.Matches(target.Labels)
+}
+
+// Silences holds the silence rules in memory. Expired rules are dropped.
+type Silences struct {
+	mu     sync.Mutex
+	rules  []SilenceRule
+	nextID int64
+}
+
+// Add validates rule, assigns its ID and stores it
+func (s *Silences) Add(rule SilenceRule) (SilenceRule, error) {
+	selector, err := parseLabelSelector(rule.Labels)
+	if err != nil {
+		return SilenceRule{}, err
+	}
+	rule.selector = selector
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	rule.ID = s.nextID
+	s.rules = append(s.rules, rule)
+	return rule, nil
+}
+
+// Remove deletes the rule with the given ID from a namespace and reports whether it existed
+func (s *Silences) Remove(namespace string, id int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune(time.Now())
+	n := len(s.rules)
+	s.rules = slices.DeleteFunc(s.rules, func(rule SilenceRule) bool {
+		return rule.ID == id && rule.Namespace == namespace
+	})
+	return len(s.rules) < n
+}
+
+// List returns the active and upcoming rules of a namespace ordered by end
+func (s *Silences) List(namespace string) []SilenceRule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune(time.Now())
+	var rules []SilenceRule
+	for _, rule := range s.rules {
+		if rule.Namespace == namespace {
+			rules = append(rules, rule)
+		}
+	}
+	slices.SortStableFunc(rules, func(a, b SilenceRule) int { return a.EndsAt.Compare(b.EndsAt) })
+	return rules
+}
+
+// Silenced reports whether any rule silences target now
+func (s *Silences) Silenced(target HealthTarget) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.prune(now)
+	return slices.ContainsFunc(s.rules, func(rule SilenceRule) bool { return rule.Matches(target, now) })
+}
+
+// prune must be called with s.mu held
+func (s *Silences) prune(now time.Time) {
+	s.rules = slices.DeleteFunc(s.rules, func(rule SilenceRule) bool { return !now.Before(rule.EndsAt) })
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxFeedItems limits the incidents in the feeds to the newest ones
+const maxFeedItems = 50
+
+// feedItem is an incident as shown in the feeds
+type feedItem struct {
+	id      string
+	title   string
+	content string
+	link    string
+	started time.Time
+	updated time.Time
+}
+
+// baseURL is the public URL of the status page, feed readers need absolute links
+func (p *StatusPage) baseURL(r *http.Request) string {
+	if p.config.URL != "" {
+		return strings.TrimSuffix(p.config.URL, "/")
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+func (p *StatusPage) feedItems(base string) []feedItem {
+	incidents := p.Incidents()
+	if len(incidents) > maxFeedItems {
+		incidents = incidents[:maxFeedItems]
+	}
+
+	items := make([]feedItem, len(incidents))
+	for i, incident := range incidents {
+		item := feedItem{
+			id:      fmt.Sprintf("%s/#incident-%d", base, incident.ID),
+			title:   incident.Title,
+			link:    fmt.Sprintf("%s/#incident-%d", base, incident.ID),
+			started: incident.StartedAt,
+			updated: incident.StartedAt,
+		}
+		lines := []string{fmt.Sprintf("%s: %s started having issues.", incident.StartedAt.UTC().Format(time.RFC1123), incident.Component)}
+		for _, update := range incident.Updates {
+			lines = append(lines, fmt.Sprintf("%s: %s", update.At.UTC().Format(time.RFC1123), update.Message))
+			item.updated = update.At
+		}
+		if incident.ResolvedAt != nil {
+			item.title += " (resolved)"
+			lines = append(lines, fmt.Sprintf("%s: Resolved.", incident.ResolvedAt.UTC().Format(time.RFC1123)))
+			if incident.ResolvedAt.After(item.updated) {
+				item.updated = *incident.ResolvedAt
+			}
+		}
+		item.content = strings.Join(lines, "\n")
+		items[i] = item
+	}
+	return items
+}
+
+// serveJSONFeed serves the incidents as a JSON Feed, see https://jsonfeed.org/version/1.1
+func (p *StatusPage) serveJSONFeed(w http.ResponseWriter, r *http.Request) {
+	type jsonFeedItem struct {
+		ID            string    `json:"id"`
+		URL           string    `json:"url"`
+		Title         string    `json:"title"`
+		ContentText   string    `json:"content_text"`
+		DatePublished time.Time `json:"date_published"`
+		DateModified  time.Time `json:"date_modified"`
+	}
+	base := p.baseURL(r)
+	feed := struct {
+		Version     string         `json:"version"`
+		Title       string         `json:"title"`
+		HomePageURL string         `json:"home_page_url"`
+		FeedURL     string         `json:"feed_url"`
+		Items       []jsonFeedItem `json:"items"`
+	}{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       p.config.Title,
+		HomePageURL: base + "/",
+		FeedURL:     base + "/feed.json",
+		Items:       []jsonFeedItem{},
+	}
+	for _, item := range p.feedItems(base) {
+		feed.Items = append(feed.Items, jsonFeedItem{
+			ID:            item.id,
+			URL:           item.link,
+			Title:         item.title,
+			ContentText:   item.content,
+			DatePublished: item.started,
+			DateModified:  item.updated,
+		})
+	}
+	data, err := json.Marshal(feed)
+	if err != nil {
+		respondError(w, r, ErrEncodeJsonBody("", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/feed+json")
+	_, _ = w.Write(data)
+}
+
+func (p *StatusPage) serveAtomFeed(w http.ResponseWriter, r *http.Request) {
+	type atomLink struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr,omitempty"`
+	}
+	type atomEntry struct {
+		ID        string   `xml:"id"`
+		Title     string   `xml:"title"`
+		Link      atomLink `xml:"link"`
+		Published string   `xml:"published"`
+		Updated   string   `xml:"updated"`
+		Content   string   `xml:"content"`
+	}
+	type atomFeed struct {
+		XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+		ID      string      `xml:"id"`
+		Title   string      `xml:"title"`
+		Updated string      `xml:"updated"`
+		Links   []atomLink  `xml:"link"`
+		Entries []atomEntry `xml:"entry"`
+	}
+
+	base := p.baseURL(r)
+	items := p.feedItems(base)
+	feed := atomFeed{
+		ID:    base + "/",
+		Title: p.config.Title,
+		Links: []atomLink{{Href: base + "/"}, {Href: base + "/feed.atom", Rel: "self"}},
+	}
+	updated := time.Unix(0, 0)
+	for _, item := range items {
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:        item.id,
+			Title:     item.title,
+			Link:      atomLink{Href: item.link},
+			Published: item.started.UTC().Format(time.RFC3339),
+			Updated:   item.updated.UTC().Format(time.RFC3339),
+			Content:   item.content,
+		})
+		if item.updated.After(updated) {
+			updated = item.updated
+		}
+	}
+	feed.Updated = updated.UTC().Format(time.RFC3339)
+	writeXML(w, "application/atom+xml", feed)
+}
+
+func (p *StatusPage) serveRSSFeed(w http.ResponseWriter, r *http.Request) {
+	type rssItem struct {
+		GUID        string `xml:"guid"`
+		Title       string `xml:"title"`
+		Link        string `xml:"link"`
+		Description string `xml:"description"`
+		PubDate     string `xml:"pubDate"`
+	}
+	type rssFeed struct {
+		XMLName     xml.Name  `xml:"rss"`
+		Version     string    `xml:"version,attr"`
+		Title       string    `xml:"channel>title"`
+		Link        string    `xml:"channel>link"`
+		Description string    `xml:"channel>description"`
+		Items       []rssItem `xml:"channel>item"`
+	}
+
+	base := p.baseURL(r)
+	feed := rssFeed{Version: "2.0", Title: p.config.Title, Link: base + "/", Description: "Incidents of " + p.config.Title}
+	for _, item := range p.feedItems(base) {
+		feed.Items = append(feed.Items, rssItem{
+			GUID:        item.id,
+			Title:       item.title,
+			Link:        item.link,
+			Description: item.content,
+			PubDate:     item.updated.UTC().Format(time.RFC1123Z),
+		})
+	}
+	writeXML(w, "application/rss+xml", feed)
+}
+
+func writeXML(w http.ResponseWriter, contentType string, v any) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		slog.Error("failed to encode feed", "error", err)
+		http.Error(w, "failed to encode feed", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(data)
+}
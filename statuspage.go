@@ -0,0 +1,428 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"gitlab.com/tozd/go/errors"
+)
+
+// StatusPageConfig publishes a read-only status page on a separate port
+type StatusPageConfig struct {
+	Port       int                     `json:"port"`
+	Title      string                  `json:"title,omitempty"`
+	URL        string                  `json:"url,omitempty"`      // public URL for the links in the feeds
+	DataFile   string                  `json:"dataFile,omitempty"` // keeps uptime and incidents across restarts
+	Components []StatusComponentConfig `json:"components"`
+}
+
+// StatusComponentConfig groups targets into a component shown on the status page
+type StatusComponentConfig struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Targets     []string `json:"targets"` // target IDs, namespace/id for targets of a namespace
+}
+
+// Statuses of a status page component
+const (
+	ComponentOperational   = "operational"    // no target has an open incident
+	ComponentPartialOutage = "partial_outage" // some targets have an open incident
+	ComponentMajorOutage   = "major_outage"   // all targets have an open incident
+	ComponentUnknown       = "unknown"        // no target was checked yet
+)
+
+const (
+	uptimeDays             = 90
+	statusPageSaveInterval = time.Minute
+	dayLayout              = "2006-01-02"
+)
+
+//go:embed templates/statuspage.html
+var statusPageTemplates embed.FS
+
+var statusPageTemplate = template.Must(template.New("statuspage.html").Funcs(template.FuncMap{
+	"percent": func(uptime float64) string { return fmt.Sprintf("%.2f%%", uptime*100) },
+	"bar": func(uptime *float64) string {
+		switch {
+		case uptime == nil:
+			return "none"
+		case *uptime >= 0.999:
+			return "up"
+		case *uptime >= 0.95:
+			return "degraded"
+		default:
+			return "down"
+		}
+	},
+}).ParseFS(statusPageTemplates, "templates/statuspage.html"))
+
+// StatusIncident is an outage of a status page component. It's opened when one of the
+// component's targets gets an incident and resolved once all of them recovered.
+type StatusIncident struct {
+	ID         int64          `json:"id"`
+	Component  string         `json:"component"`
+	Title      string         `json:"title"`
+	StartedAt  time.Time      `json:"startedAt"`
+	ResolvedAt *time.Time     `json:"resolvedAt,omitempty"`
+	Updates    []StatusUpdate `json:"updates,omitempty"`
+	Targets    []string       `json:"targets,omitempty"` // keys of the targets still failing
+}
+
+// StatusUpdate is a human-written message about an incident
+type StatusUpdate struct {
+	Message string    `json:"message"`
+	Author  string    `json:"author,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+// uptimeDay counts the checks of a component's targets on one UTC day
+type uptimeDay struct {
+	Checks  int `json:"checks"`
+	Healthy int `json:"healthy"`
+}
+
+type statusPageData struct {
+	Uptime     map[string]map[string]*uptimeDay `json:"uptime"` // component -> day -> checks
+	Incidents  []StatusIncident                 `json:"incidents"`
+	IncidentID int64                            `json:"incidentId"`
+}
+
+// StatusPage collects the uptime and incidents of the configured components from the
+// monitor's events and serves them as HTML, JSON and feeds
+type StatusPage struct {
+	config  StatusPageConfig
+	monitor *HealthMonitor
+
+	mu    sync.Mutex
+	data  statusPageData
+	dirty bool
+}
+
+// NewStatusPage creates a StatusPage and loads its data file if it exists
+func NewStatusPage(config StatusPageConfig, monitor *HealthMonitor) (*StatusPage, error) {
+	if config.Title == "" {
+		config.Title = "Status"
+	}
+	p := &StatusPage{
+		config:  config,
+		monitor: monitor,
+		data:    statusPageData{Uptime: make(map[string]map[string]*uptimeDay)},
+	}
+	if config.DataFile == "" {
+		return p, nil
+	}
+	data, err := os.ReadFile(config.DataFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return p, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to read status page data")
+	}
+	if err := json.Unmarshal(data, &p.data); err != nil {
+		return nil, errors.Wrapf(err, "invalid status page data file %s", config.DataFile)
+	}
+	if p.data.Uptime == nil {
+		p.data.Uptime = make(map[string]map[string]*uptimeDay)
+	}
+	return p, nil
+}
+
+// Run records the events of the components' targets and saves the data periodically
+// until ctx is done. If Run falls behind the events it resubscribes, missing the
+// events in between rather than the rest of them.
+func (p *StatusPage) Run(ctx context.Context) {
+	go p.saveLoop(ctx)
+
+	filter := func(event Event) bool {
+		return (event.Type == EventCheck || event.Type == EventIncident) && len(p.components(event.Status.Target.Key())) > 0
+	}
+	sub := p.monitor.Subscribe(filter)
+	defer func() { sub.Close() }()
+	for {
+		select {
+		case event, ok := <-sub.Events:
+			if ok {
+				p.record(event)
+				continue
+			}
+			if !sub.Dropped() || ctx.Err() != nil {
+				return
+			}
+			slog.Warn("status page fell behind the events, some checks were not recorded")
+			sub = p.monitor.Subscribe(filter)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// saveLoop saves the data every statusPageSaveInterval until ctx is done. It runs
+// separately from recording, so a slow disk doesn't make Run fall behind.
+func (p *StatusPage) saveLoop(ctx context.Context) {
+	ticker := time.NewTicker(statusPageSaveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.Save(); err != nil {
+				slog.Error("failed to save status page data", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// components returns the names of the components target belongs to
+func (p *StatusPage) components(key string) []string {
+	var names []string
+	for _, component := range p.config.Components {
+		if slices.Contains(component.Targets, key) {
+			names = append(names, component.Name)
+		}
+	}
+	return names
+}
+
+func (p *StatusPage) record(event Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.dirty = true
+
+	key := event.Status.Target.Key()
+	for _, component := range p.components(key) {
+		switch event.Type {
+		case EventCheck:
+			day := event.Status.Result.Timestamp.UTC().Format(dayLayout)
+			days := p.data.Uptime[component]
+			if days == nil {
+				days = make(map[string]*uptimeDay)
+				p.data.Uptime[component] = days
+			}
+			if days[day] == nil {
+				days[day] = &uptimeDay{}
+			}
+			days[day].Checks++
+			if event.Status.Result.Healthy {
+				days[day].Healthy++
+			}
+		case EventIncident:
+			p.recordIncident(component, key, event)
+		}
+	}
+	p.prune(time.Now())
+}
+
+// recordIncident must be called with p.mu held
+func (p *StatusPage) recordIncident(component, key string, event Event) {
+	incident := p.openIncident(component)
+	switch {
+	case event.Incident == IncidentOpened && incident == nil:
+		p.data.IncidentID++
+		p.data.Incidents = append(p.data.Incidents, StatusIncident{
+			ID:        p.data.IncidentID,
+			Component: component,
+			Title:     component + " is experiencing issues",
+			StartedAt: event.Status.Result.Timestamp,
+			Targets:   []string{key},
+		})
+	case event.Incident == IncidentOpened:
+		if !slices.Contains(incident.Targets, key) {
+			incident.Targets = append(incident.Targets, key)
+		}
+	case (event.Incident == IncidentResolved || event.Incident == IncidentRemoved) && incident != nil:
+		resolvedAt := event.Status.Result.Timestamp
+		if event.Incident == IncidentRemoved {
+			// The target didn't recover, so the page says why it's no longer part of the incident
+			resolvedAt = time.Now()
+			incident.Updates = append(incident.Updates, StatusUpdate{Message: key + " is no longer monitored", At: resolvedAt})
+		}
+		incident.Targets = slices.DeleteFunc(incident.Targets, func(k string) bool { return k == key })
+		if len(incident.Targets) == 0 {
+			incident.ResolvedAt = &resolvedAt
+			incident.Targets = nil
+		}
+	}
+}
+
+// openIncident must be called with p.mu held
+func (p *StatusPage) openIncident(component string) *StatusIncident {
+	for i := range p.data.Incidents {
+		if p.data.Incidents[i].Component == component && p.data.Incidents[i].ResolvedAt == nil {
+			return &p.data.Incidents[i]
+		}
+	}
+	return nil
+}
+
+// prune forgets days and resolved incidents older than the uptime window, it must be
+// called with p.mu held
+func (p *StatusPage) prune(now time.Time) {
+	oldest := now.UTC().AddDate(0, 0, -uptimeDays+1).Format(dayLayout)
+	for _, days := range p.data.Uptime {
+		for day := range days {
+			if day < oldest {
+				delete(days, day)
+			}
+		}
+	}
+	p.data.Incidents = slices.DeleteFunc(p.data.Incidents, func(incident StatusIncident) bool {
+		return incident.ResolvedAt != nil && incident.ResolvedAt.UTC().Format(dayLayout) < oldest
+	})
+}
+
+// AddUpdate adds a human-written message to an incident, it returns false if there is no such incident
+func (p *StatusPage) AddUpdate(id int64, update StatusUpdate) (StatusIncident, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.data.Incidents {
+		if p.data.Incidents[i].ID == id {
+			p.data.Incidents[i].Updates = append(p.data.Incidents[i].Updates, update)
+			p.dirty = true
+			return p.data.Incidents[i], true
+		}
+	}
+	return StatusIncident{}, false
+}
+
+// Incidents returns the incidents of the last 90 days, newest first
+func (p *StatusPage) Incidents() []StatusIncident {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	incidents := slices.Clone(p.data.Incidents)
+	slices.Reverse(incidents)
+	return incidents
+}
+
+// Save writes the data file if anything changed since the last save
+func (p *StatusPage) Save() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.config.DataFile == "" || !p.dirty {
+		return nil
+	}
+	data, err := json.Marshal(p.data)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(p.config.DataFile, data, 0o644); err != nil {
+		return err
+	}
+	p.dirty = false
+	return nil
+}
+
+// statusSummary is the content of the status page
+type statusSummary struct {
+	Title      string            `json:"title"`
+	Status     string            `json:"status"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
+	Components []componentStatus `json:"components"`
+	Incidents  []StatusIncident  `json:"incidents"`
+}
+
+type componentStatus struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Status      string      `json:"status"`
+	Uptime      *float64    `json:"uptime,omitempty"` // over the last 90 days
+	Days        []uptimeBar `json:"days"`             // oldest first
+}
+
+type uptimeBar struct {
+	Date   string   `json:"date"`
+	Uptime *float64 `json:"uptime,omitempty"` // missing if there were no checks
+}
+
+func (p *StatusPage) summary(now time.Time) statusSummary {
+	summary := statusSummary{Title: p.config.Title, Status: ComponentOperational, UpdatedAt: now, Incidents: p.Incidents()}
+	for i := range summary.Incidents {
+		// Target keys and authors are only shown through the API
+		summary.Incidents[i].Targets = nil
+		updates := slices.Clone(summary.Incidents[i].Updates)
+		for j := range updates {
+			updates[j].Author = ""
+		}
+		summary.Incidents[i].Updates = updates
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, component := range p.config.Components {
+		status := componentStatus{Name: component.Name, Description: component.Description, Status: p.componentStatus(component)}
+		var checks, healthy int
+		for day := uptimeDays - 1; day >= 0; day-- {
+			date := now.UTC().AddDate(0, 0, -day).Format(dayLayout)
+			bar := uptimeBar{Date: date}
+			if counts := p.data.Uptime[component.Name][date]; counts != nil && counts.Checks > 0 {
+				uptime := float64(counts.Healthy) / float64(counts.Checks)
+				bar.Uptime = &uptime
+				checks += counts.Checks
+				healthy += counts.Healthy
+			}
+			status.Days = append(status.Days, bar)
+		}
+		if checks > 0 {
+			uptime := float64(healthy) / float64(checks)
+			status.Uptime = &uptime
+		}
+		summary.Components = append(summary.Components, status)
+
+		if status.Status == ComponentMajorOutage ||
+			status.Status == ComponentPartialOutage && summary.Status != ComponentMajorOutage {
+			summary.Status = status.Status
+		}
+	}
+	return summary
+}
+
+func (p *StatusPage) componentStatus(component StatusComponentConfig) string {
+	checked, alerted := 0, 0
+	for _, key := range component.Targets {
+		status, ok := p.monitor.Status(key)
+		if !ok {
+			continue
+		}
+		checked++
+		if status.Alerted {
+			alerted++
+		}
+	}
+	switch {
+	case checked == 0:
+		return ComponentUnknown
+	case alerted == 0:
+		return ComponentOperational
+	case alerted == checked:
+		return ComponentMajorOutage
+	default:
+		return ComponentPartialOutage
+	}
+}
+
+// Handler serves the status page. It only exposes the component names, uptime and
+// incidents, never target IDs or URLs.
+func (p *StatusPage) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := statusPageTemplate.Execute(w, p.summary(time.Now())); err != nil {
+			slog.Error("failed to render status page", "error", err)
+		}
+	})
+	mux.HandleFunc("GET /status.json", func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, r, http.StatusOK, p.summary(time.Now()))
+	})
+	mux.HandleFunc("GET /feed.json", p.serveJSONFeed)
+	mux.HandleFunc("GET /feed.atom", p.serveAtomFeed)
+	mux.HandleFunc("GET /feed.rss", p.serveRSSFeed)
+	return mux
+}
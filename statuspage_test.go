@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatusPage(t *testing.T) {
+	checker, _ := NewHealthChecker(time.Second, nil)
+	notifications, _ := NewNotificationQueue(nil, NotificationConfig{})
+	monitor := NewHealthMonitor(checker, time.Hour, notifications)
+	config := StatusPageConfig{
+		Title:    "Example Status",
+		DataFile: filepath.Join(t.TempDir(), "statuspage.json"),
+		Components: []StatusComponentConfig{
+			{Name: "Website", Targets: []string{"example"}},
+			{Name: "Payments", Description: "Card payments", Targets: []string{"payments"}},
+		},
+	}
+	page, err := NewStatusPage(config, monitor)
+	if err != nil {
+		t.Fatalf("Failed to create status page: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go page.Run(ctx)
+	waitFor(t, time.Second, func() bool {
+		monitor.events.mu.Lock()
+		defer monitor.events.mu.Unlock()
+		return len(monitor.events.subscribers) > 0
+	})
+
+	router := http.NewServeMux()
+	api := NewServer(checker, monitor, notifications)
+	api.SetStatusPage(page)
+	HandlerFromMux(api, router)
+	apiServer := httptest.NewServer(router)
+	t.Cleanup(apiServer.Close)
+	statusServer := httptest.NewServer(page.Handler())
+	t.Cleanup(statusServer.Close)
+
+	get := func(path string) string {
+		t.Helper()
+		resp, err := http.Get(statusServer.URL + path)
+		if err != nil {
+			t.Fatalf("Failed to get %s: %v", path, err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected 200 for %s, got %d: %s", path, resp.StatusCode, body)
+		}
+		return string(body)
+	}
+	summary := func() statusSummary {
+		t.Helper()
+		var summary statusSummary
+		if err := json.Unmarshal([]byte(get("/status.json")), &summary); err != nil {
+			t.Fatalf("Failed to decode status: %v", err)
+		}
+		return summary
+	}
+
+	if err := checker.AddTarget(context.Background(), testResult(true).Target); err != nil {
+		t.Fatalf("Failed to add target: %v", err)
+	}
+	// Not part of a component, so it never shows up
+	other := testResult(false)
+	other.Target.ID = "other"
+	monitor.processResult(other)
+	monitor.processResult(other)
+
+	monitor.processResult(testResult(true))
+	monitor.processResult(testResult(false))
+	monitor.processResult(testResult(false))
+	waitFor(t, time.Second, func() bool { return len(page.Incidents()) == 1 })
+
+	s := summary()
+	if s.Status != ComponentMajorOutage || s.Components[0].Status != ComponentMajorOutage || s.Components[1].Status != ComponentUnknown {
+		t.Fatalf("Expected the website to be down and payments unknown, got %+v", s)
+	}
+	if len(s.Components[0].Days) != uptimeDays || s.Components[0].Days[uptimeDays-1].Uptime == nil || s.Components[0].Days[0].Uptime != nil {
+		t.Fatalf("Expected 90 days with only today checked, got %+v", s.Components[0].Days)
+	}
+	if uptime := *s.Components[0].Uptime; uptime < 0.33 || uptime > 0.34 {
+		t.Fatalf("Expected an uptime of 1/3, got %f", uptime)
+	}
+	if len(s.Incidents) != 1 || s.Incidents[0].Component != "Website" || s.Incidents[0].ResolvedAt != nil {
+		t.Fatalf("Expected an open website incident, got %+v", s.Incidents)
+	}
+	if strings.Contains(get("/status.json"), "example") {
+		t.Fatalf("Expected target IDs to stay private")
+	}
+
+	resp, body := doRequest(t, http.MethodPost, apiServer.URL+"/status-page/incidents/1/updates", `{"message": "We are investigating"}`)
+	if resp.StatusCode != http.StatusCreated || !strings.Contains(body, "We are investigating") {
+		t.Fatalf("Expected the update to be added, got %d: %s", resp.StatusCode, body)
+	}
+	resp, body = doRequest(t, http.MethodPost, apiServer.URL+"/status-page/incidents/7/updates", `{"message": "Unknown"}`)
+	if resp.StatusCode != http.StatusNotFound || !strings.Contains(body, "status_page_incident_not_found") {
+		t.Fatalf("Expected 404, got %d: %s", resp.StatusCode, body)
+	}
+	resp, body = doRequest(t, http.MethodPost, apiServer.URL+"/status-page/incidents/1/updates", `{"message": " "}`)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for an empty message, got %d: %s", resp.StatusCode, body)
+	}
+
+	monitor.processResult(testResult(true))
+	waitFor(t, time.Second, func() bool { return page.Incidents()[0].ResolvedAt != nil })
+
+	if html := get("/"); !strings.Contains(html, "Example Status") || !strings.Contains(html, "We are investigating") || !strings.Contains(html, "Card payments") {
+		t.Fatalf("Expected the page to show the components and updates, got %s", html)
+	}
+	for _, feed := range []string{"/feed.atom", "/feed.rss", "/feed.json"} {
+		if body := get(feed); !strings.Contains(body, "Website is experiencing issues (resolved)") || !strings.Contains(body, "We are investigating") {
+			t.Fatalf("Expected %s to contain the incident, got %s", feed, body)
+		}
+	}
+
+	// The uptime and incidents survive a restart
+	cancel()
+	if err := page.Save(); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+	restored, err := NewStatusPage(config, monitor)
+	if err != nil {
+		t.Fatalf("Failed to load status page: %v", err)
+	}
+	if incidents := restored.Incidents(); len(incidents) != 1 || len(incidents[0].Updates) != 1 {
+		t.Fatalf("Expected the incident to be restored, got %+v", incidents)
+	}
+	if restored.summary(time.Now()).Components[0].Uptime == nil {
+		t.Fatalf("Expected the uptime to be restored")
+	}
+}
+
+func TestStatusPageRemovedTarget(t *testing.T) {
+	checker, _ := NewHealthChecker(time.Second, nil)
+	page, err := NewStatusPage(StatusPageConfig{
+		DataFile:   filepath.Join(t.TempDir(), "statuspage.json"),
+		Components: []StatusComponentConfig{{Name: "Website", Targets: []string{"example", "www"}}},
+	}, NewHealthMonitor(checker, time.Hour, nil))
+	if err != nil {
+		t.Fatalf("Failed to create status page: %v", err)
+	}
+
+	www := testResult(false)
+	www.Target.ID = "www"
+	page.record(Event{Type: EventIncident, Status: TargetStatus{Target: www.Target, Result: www}, Incident: IncidentOpened})
+	page.record(Event{Type: EventIncident, Status: TargetStatus{Target: testResult(false).Target, Result: testResult(false)}, Incident: IncidentOpened})
+	page.record(Event{Type: EventIncident, Status: TargetStatus{Target: www.Target, Result: www}, Incident: IncidentRemoved})
+	if incidents := page.Incidents(); len(incidents) != 1 || incidents[0].ResolvedAt != nil || len(incidents[0].Updates) != 1 || incidents[0].Updates[0].Message != "www is no longer monitored" {
+		t.Fatalf("Expected the incident to stay open with an update about the removed target, got %+v", incidents)
+	}
+
+	page.record(Event{Type: EventIncident, Status: TargetStatus{Target: testResult(false).Target, Result: testResult(false)}, Incident: IncidentRemoved})
+	if incidents := page.Incidents(); incidents[0].ResolvedAt == nil || len(incidents[0].Updates) != 2 {
+		t.Fatalf("Expected the incident to be resolved once all its targets were removed, got %+v", incidents)
+	}
+}
+
+func TestStatusPageFallsBehind(t *testing.T) {
+	checker, _ := NewHealthChecker(time.Second, nil)
+	notifications, _ := NewNotificationQueue(nil, NotificationConfig{})
+	monitor := NewHealthMonitor(checker, time.Hour, notifications)
+	if err := checker.AddTarget(context.Background(), testResult(true).Target); err != nil {
+		t.Fatalf("Failed to add target: %v", err)
+	}
+	page, _ := NewStatusPage(StatusPageConfig{Components: []StatusComponentConfig{{Name: "Website", Targets: []string{"example"}}}}, monitor)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go page.Run(ctx)
+	subscribed := func() bool {
+		monitor.events.mu.Lock()
+		defer monitor.events.mu.Unlock()
+		return len(monitor.events.subscribers) > 0
+	}
+	waitFor(t, time.Second, subscribed)
+	checks := func() int {
+		page.mu.Lock()
+		defer page.mu.Unlock()
+		day := page.data.Uptime["Website"][time.Now().UTC().Format(dayLayout)]
+		if day == nil {
+			return 0
+		}
+		return day.Checks
+	}
+
+	// Recording is blocked, so the subscription overflows and is dropped
+	page.mu.Lock()
+	for range subscriptionBuffer + 10 {
+		monitor.processResult(testResult(true))
+	}
+	if subscribed() {
+		t.Fatalf("Expected the subscription to be dropped")
+	}
+	page.mu.Unlock()
+
+	waitFor(t, time.Second, subscribed)
+	recorded := checks()
+	monitor.processResult(testResult(true))
+	waitFor(t, time.Second, func() bool { return checks() == recorded+1 })
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gitlab.com/tozd/go/errors"
+)
+
+// targetFileBackups is the number of previous versions of the targets file kept as
+// <file>.1 (newest) to <file>.N (oldest)
+const targetFileBackups = 3
+
+var ErrTargetFileLocked = errors.New("targets file is used by another process")
+
+// TargetFile persists targets to a JSON file. Writes are atomic, the previous
+// versions are kept as rotating backups and the file is locked for the lifetime of
+// the TargetFile, so two processes can't overwrite each other's targets.
+type TargetFile struct {
+	path    string
+	recover bool
+	lock    *os.File
+}
+
+// OpenTargetFile locks the targets file at path. If recover is set, Load falls back
+// to the newest backup that can be read when the file is corrupt.
+func OpenTargetFile(path string, recover bool) (*TargetFile, error) {
+	lock, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open targets lock file")
+	}
+	if err := lockFile(lock); err != nil {
+		lock.Close()
+		if errors.Is(err, ErrTargetFileLocked) {
+			return nil, errors.Errorf("%s: %w", path, err)
+		}
+		return nil, errors.Wrap(err, "failed to lock targets file")
+	}
+
+	return &TargetFile{path: path, recover: recover, lock: lock}, nil
+}
+
+// Load reads the targets. A missing file is not an error.
+func (f *TargetFile) Load() ([]HealthTarget, error) {
+	targets, err := readTargetFile(f.path)
+	if err == nil || os.IsNotExist(err) {
+		return targets, nil
+	}
+	if !f.recover {
+		return nil, errors.Errorf("%w, start with -recover-targets to restore the last good backup", err)
+	}
+
+	for i := 1; i <= targetFileBackups; i++ {
+		backup := fmt.Sprintf("%s.%d", f.path, i)
+		targets, backupErr := readTargetFile(backup)
+		if backupErr != nil {
+			slog.Warn("skipping unusable targets backup", "path", backup, "error", backupErr)
+			continue
+		}
+
+		// Keep the corrupt file around for inspection, the next save replaces it
+		corrupt := fmt.Sprintf("%s.corrupt-%d", f.path, time.Now().Unix())
+		if err := os.Rename(f.path, corrupt); err != nil {
+			return nil, errors.Wrap(err, "failed to move corrupt targets file aside")
+		}
+		slog.Warn("recovered targets from backup", "path", backup, "corrupt", corrupt, "error", err)
+		return targets, nil
+	}
+
+	return nil, errors.Errorf("%w, and no usable backup was found", err)
+}
+
+// Save atomically replaces the targets file, keeping the previous version as a backup
+func (f *TargetFile) Save(targets []HealthTarget) error {
+	data, err := json.MarshalIndent(targets, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal targets data")
+	}
+
+	if err := f.rotateBackups(); err != nil {
+		// Losing a backup is no reason to lose the change
+		slog.Warn("failed to back up targets file", "path", f.path, "error", err)
+	}
+
+	if err := writeFileAtomic(f.path, data, 0644); err != nil {
+		return errors.Wrap(err, "failed to write targets file")
+	}
+	return nil
+}
+
+// Close releases the lock on the targets file
+func (f *TargetFile) Close() error {
+	return f.lock.Close()
+}
+
+// rotateBackups shifts the backups by one and copies the current file to <file>.1.
+// Files that can't be read are not backed up, so the backups stay loadable.
+func (f *TargetFile) rotateBackups() error {
+	data, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if _, err := parseTargets(data); err != nil {
+		return nil
+	}
+
+	for i := targetFileBackups - 1; i >= 1; i-- {
+		err := os.Rename(fmt.Sprintf("%s.%d", f.path, i), fmt.Sprintf("%s.%d", f.path, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return writeFileAtomic(f.path+".1", data, 0644)
+}
+
+func readTargetFile(path string) ([]HealthTarget, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, errors.Wrap(err, "failed to read targets file")
+	}
+
+	targets, err := parseTargets(data)
+	if err != nil {
+		return nil, errors.Errorf("targets file %s is corrupt: %w", path, err)
+	}
+	return targets, nil
+}
+
+func parseTargets(data []byte) ([]HealthTarget, error) {
+	var targets []HealthTarget
+	if err := json.Unmarshal(data, &targets); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal targets data")
+	}
+
+	// Parse URL strings back into URL objects
+	for i := range targets {
+		parsedURL, err := url.Parse(targets[i].URLString)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse URL from stored target")
+		}
+		targets[i].URL = parsedURL
+	}
+	return targets, nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it over
+// path once it is synced to disk, so readers never see a partially written file
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	// Persist the rename itself, not supported on every platform
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		d.Close()
+	}
+	return nil
+}
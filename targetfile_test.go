@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gitlab.com/tozd/go/errors"
+)
+
+func mustOpenTargetFile(t *testing.T, path string, recover bool) *TargetFile {
+	t.Helper()
+	f, err := OpenTargetFile(path, recover)
+	if err != nil {
+		t.Fatalf("Failed to open targets file: %v", err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestTargetFile(t *testing.T) {
+	t.Run("Keeps the previous versions as backups", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "targets.json")
+		f := mustOpenTargetFile(t, path, false)
+
+		for i := 1; i <= targetFileBackups+2; i++ {
+			target := mustTarget(t, fmt.Sprintf("target-%d", i), "https://example.com")
+			if err := f.Save([]HealthTarget{target}); err != nil {
+				t.Fatalf("Failed to save targets: %v", err)
+			}
+		}
+
+		for i := 1; i <= targetFileBackups; i++ {
+			targets, err := readTargetFile(fmt.Sprintf("%s.%d", path, i))
+			if err != nil {
+				t.Fatalf("Failed to read backup %d: %v", i, err)
+			}
+			if want := fmt.Sprintf("target-%d", targetFileBackups+2-i); targets[0].ID != want {
+				t.Fatalf("Expected backup %d to contain %s, got %s", i, want, targets[0].ID)
+			}
+		}
+		if _, err := os.Stat(fmt.Sprintf("%s.%d", path, targetFileBackups+1)); !os.IsNotExist(err) {
+			t.Fatalf("Expected only %d backups, got %v", targetFileBackups, err)
+		}
+		if matches, _ := filepath.Glob(path + ".tmp-*"); len(matches) != 0 {
+			t.Fatalf("Expected temporary files to be cleaned up, got %v", matches)
+		}
+	})
+
+	t.Run("Recovers from the last good backup", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "targets.json")
+		f := mustOpenTargetFile(t, path, false)
+		for _, id := range []string{"old", "new"} {
+			if err := f.Save([]HealthTarget{mustTarget(t, id, "https://example.com")}); err != nil {
+				t.Fatalf("Failed to save targets: %v", err)
+			}
+		}
+		f.Close()
+
+		// Simulate a torn write by a crashed process
+		if err := os.WriteFile(path, []byte(`[{"id": "ne`), 0644); err != nil {
+			t.Fatalf("Failed to corrupt targets file: %v", err)
+		}
+
+		f = mustOpenTargetFile(t, path, false)
+		if _, err := f.Load(); err == nil {
+			t.Fatal("Expected loading a corrupt targets file to fail without recovery")
+		}
+		f.Close()
+
+		targets, err := mustOpenTargetFile(t, path, true).Load()
+		if err != nil {
+			t.Fatalf("Failed to recover targets: %v", err)
+		}
+		if len(targets) != 1 || targets[0].ID != "old" || targets[0].URL == nil {
+			t.Fatalf("Expected the backup to be restored, got %+v", targets)
+		}
+		if matches, _ := filepath.Glob(path + ".corrupt-*"); len(matches) != 1 {
+			t.Fatalf("Expected the corrupt file to be kept, got %v", matches)
+		}
+	})
+
+	t.Run("Refuses a file locked by another process", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "targets.json")
+		mustOpenTargetFile(t, path, false)
+
+		_, err := OpenTargetFile(path, false)
+		if !errors.Is(err, ErrTargetFileLocked) {
+			t.Fatalf("Expected ErrTargetFileLocked, got %v", err)
+		}
+	})
+}
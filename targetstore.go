@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"gitlab.com/tozd/go/errors"
+)
+
+// Target store backends
+const (
+	TargetStoreFile   = "file"
+	TargetStoreSQLite = "sqlite"
+	TargetStoreRedis  = "redis"
+)
+
+// TargetStore persists the targets registered through the API. Stores other than
+// the file can be shared by several Doctor replicas, which pick up each other's
+// changes on their next check round.
+type TargetStore interface {
+	// List returns all stored targets with their URL parsed
+	List(ctx context.Context) ([]HealthTarget, error)
+	// Put adds the target or replaces the one with the same key
+	Put(ctx context.Context, target HealthTarget) error
+	// Delete removes the target, it is not an error if it doesn't exist
+	Delete(ctx context.Context, key string) error
+	// Apply puts and removes many targets at once, either all changes are stored or none
+	Apply(ctx context.Context, put []HealthTarget, remove []string) error
+	Close() error
+}
+
+// TargetStoreConfig selects the backend targets registered through the API are persisted to
+type TargetStoreConfig struct {
+	Type string `json:"type"`
+	Path string `json:"path,omitempty"` // file and sqlite
+	URL  string `json:"url,omitempty"`  // redis, e.g. redis://localhost:6379/0
+	Key  string `json:"key,omitempty"`  // redis hash the targets are stored in
+}
+
+// OpenTargetStore opens the target store configured in config. It returns nil if
+// neither targetStore nor targetFile is set, in which case targets aren't persisted.
+func OpenTargetStore(ctx context.Context, config *Config, recover bool) (TargetStore, error) {
+	storeConfig := config.TargetStore
+	if storeConfig == nil {
+		if config.TargetFile == "" {
+			return nil, nil
+		}
+		storeConfig = &TargetStoreConfig{Type: TargetStoreFile, Path: config.TargetFile}
+	}
+
+	switch storeConfig.Type {
+	case TargetStoreFile:
+		return NewFileTargetStore(storeConfig.Path, recover)
+	case TargetStoreSQLite:
+		return NewSQLiteTargetStore(ctx, storeConfig.Path)
+	case TargetStoreRedis:
+		return NewRedisTargetStore(ctx, storeConfig.URL, storeConfig.Key)
+	default:
+		return nil, errors.Errorf("unknown target store type %q", storeConfig.Type)
+	}
+}
+
+// FileTargetStore keeps the targets in memory and writes all of them to a TargetFile
+// on every change. The file is locked, so it can't be shared between replicas.
+type FileTargetStore struct {
+	file    *TargetFile
+	mu      sync.Mutex
+	targets map[string]HealthTarget
+}
+
+// NewFileTargetStore opens the targets file at path and loads its targets
+func NewFileTargetStore(path string, recover bool) (*FileTargetStore, error) {
+	file, err := OpenTargetFile(path, recover)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := file.Load()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	s := &FileTargetStore{file: file, targets: make(map[string]HealthTarget, len(targets))}
+	for _, target := range targets {
+		s.targets[target.Key()] = target
+	}
+	return s, nil
+}
+
+func (s *FileTargetStore) List(ctx context.Context) ([]HealthTarget, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return MapValues(s.targets), nil
+}
+
+func (s *FileTargetStore) Put(ctx context.Context, target HealthTarget) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.targets[target.Key()]
+	s.targets[target.Key()] = target
+	if err := s.save(); err != nil {
+		if existed {
+			s.targets[target.Key()] = previous
+		} else {
+			delete(s.targets, target.Key())
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *FileTargetStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, ok := s.targets[id]
+	if !ok {
+		return nil
+	}
+	delete(s.targets, id)
+	if err := s.save(); err != nil {
+		s.targets[id] = previous
+		return err
+	}
+	return nil
+}
+
+func (s *FileTargetStore) Apply(ctx context.Context, put []HealthTarget, remove []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous := maps.Clone(s.targets)
+	for _, target := range put {
+		s.targets[target.Key()] = target
+	}
+	for _, id := range remove {
+		delete(s.targets, id)
+	}
+	if err := s.save(); err != nil {
+		s.targets = previous
+		return err
+	}
+	return nil
+}
+
+func (s *FileTargetStore) Close() error {
+	return s.file.Close()
+}
+
+// save must be called with s.mu held
+func (s *FileTargetStore) save() error {
+	targets := MapValues(s.targets)
+	slices.SortFunc(targets, func(a, b HealthTarget) int { return strings.Compare(a.Key(), b.Key()) })
+	return s.file.Save(targets)
+}
+
+// unmarshalTarget decodes a target stored as JSON by the SQLite and Redis stores
+func unmarshalTarget(data []byte) (HealthTarget, error) {
+	var target HealthTarget
+	if err := json.Unmarshal(data, &target); err != nil {
+		return target, errors.Wrap(err, "failed to unmarshal target")
+	}
+	parsedURL, err := url.Parse(target.URLString)
+	if err != nil {
+		return target, errors.Wrap(err, "failed to parse URL from stored target")
+	}
+	target.URL = parsedURL
+	return target, nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/redis/go-redis/v9"
+	"gitlab.com/tozd/go/errors"
+)
+
+const defaultRedisTargetKey = "doctor:targets"
+
+// RedisTargetStore stores the targets as JSON values of a Redis hash keyed by target key
+type RedisTargetStore struct {
+	client *redis.Client
+	key    string
+}
+
+// NewRedisTargetStore connects to the Redis server at rawURL. If key is empty,
+// doctor:targets is used.
+func NewRedisTargetStore(ctx context.Context, rawURL, key string) (*RedisTargetStore, error) {
+	options, err := redis.ParseURL(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid redis url")
+	}
+	if key == "" {
+		key = defaultRedisTargetKey
+	}
+
+	client := redis.NewClient(options)
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, errors.Wrap(err, "failed to connect to redis")
+	}
+
+	return &RedisTargetStore{client: client, key: key}, nil
+}
+
+func (s *RedisTargetStore) List(ctx context.Context) ([]HealthTarget, error) {
+	values, err := s.client.HGetAll(ctx, s.key).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list targets")
+	}
+
+	targets := make([]HealthTarget, 0, len(values))
+	for key, value := range values {
+		target, err := unmarshalTarget([]byte(value))
+		if err != nil {
+			// One broken entry mustn't take all other targets down with it
+			slog.Warn("skipping undecodable target in the target store", "key", key, "error", err)
+			continue
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+func (s *RedisTargetStore) Put(ctx context.Context, target HealthTarget) error {
+	data, err := json.Marshal(target)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal target")
+	}
+	return errors.Wrap(s.client.HSet(ctx, s.key, target.Key(), data).Err(), "failed to store target")
+}
+
+func (s *RedisTargetStore) Delete(ctx context.Context, id string) error {
+	return errors.Wrap(s.client.HDel(ctx, s.key, id).Err(), "failed to delete target")
+}
+
+func (s *RedisTargetStore) Apply(ctx context.Context, put []HealthTarget, remove []string) error {
+	values := make([]any, 0, 2*len(put))
+	for _, target := range put {
+		data, err := json.Marshal(target)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal target")
+		}
+		values = append(values, target.Key(), data)
+	}
+
+	// MULTI/EXEC, so other replicas never see half of the changes
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(values) > 0 {
+			pipe.HSet(ctx, s.key, values...)
+		}
+		if len(remove) > 0 {
+			pipe.HDel(ctx, s.key, remove...)
+		}
+		return nil
+	})
+	return errors.Wrap(err, "failed to apply targets")
+}
+
+func (s *RedisTargetStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+
+	_ "github.com/mattn/go-sqlite3"
+	"gitlab.com/tozd/go/errors"
+)
+
+// SQLiteTargetStore stores the targets in a SQLite database. Replicas on the same
+// host or on a shared volume with working locks can use the same database file.
+type SQLiteTargetStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteTargetStore opens the database at path, creating it if necessary
+func NewSQLiteTargetStore(ctx context.Context, path string) (*SQLiteTargetStore, error) {
+	// WAL lets replicas read while another one writes, busy_timeout waits for their locks
+	db, err := sql.Open("sqlite3", "file:"+path+"?_journal_mode=WAL&_busy_timeout=5000")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open target database")
+	}
+
+	_, err = db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS targets (
+		id   TEXT PRIMARY KEY,
+		data TEXT NOT NULL
+	)`)
+	if err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "failed to create targets table")
+	}
+
+	return &SQLiteTargetStore{db: db}, nil
+}
+
+func (s *SQLiteTargetStore) List(ctx context.Context) ([]HealthTarget, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, data FROM targets ORDER BY id`)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query targets")
+	}
+	defer rows.Close()
+
+	var targets []HealthTarget
+	for rows.Next() {
+		var id string
+		var data []byte
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, errors.Wrap(err, "failed to read target")
+		}
+		target, err := unmarshalTarget(data)
+		if err != nil {
+			// One broken entry mustn't take all other targets down with it
+			slog.Warn("skipping undecodable target in the target store", "key", id, "error", err)
+			continue
+		}
+		targets = append(targets, target)
+	}
+	return targets, errors.Wrap(rows.Err(), "failed to query targets")
+}
+
+func (s *SQLiteTargetStore) Put(ctx context.Context, target HealthTarget) error {
+	data, err := json.Marshal(target)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal target")
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO targets (id, data) VALUES (?, ?) ON CONFLICT (id) DO UPDATE SET data = excluded.data`,
+		target.Key(), string(data),
+	)
+	return errors.Wrap(err, "failed to store target")
+}
+
+func (s *SQLiteTargetStore) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM targets WHERE id = ?`, id)
+	return errors.Wrap(err, "failed to delete target")
+}
+
+func (s *SQLiteTargetStore) Apply(ctx context.Context, put []HealthTarget, remove []string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback() // no-op after commit
+
+	for _, target := range put {
+		data, err := json.Marshal(target)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal target")
+		}
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO targets (id, data) VALUES (?, ?) ON CONFLICT (id) DO UPDATE SET data = excluded.data`,
+			target.Key(), string(data),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to store target")
+		}
+	}
+	for _, id := range remove {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM targets WHERE id = ?`, id); err != nil {
+			return errors.Wrap(err, "failed to delete target")
+		}
+	}
+
+	return errors.Wrap(tx.Commit(), "failed to commit transaction")
+}
+
+func (s *SQLiteTargetStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestTargetStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) TargetStore{
+		TargetStoreFile: func(t *testing.T) TargetStore {
+			store, err := NewFileTargetStore(filepath.Join(t.TempDir(), "targets.json"), false)
+			if err != nil {
+				t.Fatalf("Failed to open file store: %v", err)
+			}
+			return store
+		},
+		TargetStoreSQLite: func(t *testing.T) TargetStore {
+			store, err := NewSQLiteTargetStore(context.Background(), filepath.Join(t.TempDir(), "targets.db"))
+			if err != nil {
+				t.Fatalf("Failed to open SQLite store: %v", err)
+			}
+			return store
+		},
+		TargetStoreRedis: func(t *testing.T) TargetStore {
+			server := miniredis.RunT(t)
+			store, err := NewRedisTargetStore(context.Background(), "redis://"+server.Addr(), "")
+			if err != nil {
+				t.Fatalf("Failed to open Redis store: %v", err)
+			}
+			return store
+		},
+	}
+
+	for name, open := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store := open(t)
+			defer store.Close()
+
+			target := mustTarget(t, "example", "https://example.com")
+			target.TimeoutInSec = 5
+			if err := store.Put(ctx, target); err != nil {
+				t.Fatalf("Failed to put target: %v", err)
+			}
+			if err := store.Put(ctx, mustTarget(t, "other", "https://other.example.com")); err != nil {
+				t.Fatalf("Failed to put target: %v", err)
+			}
+			target = mustTarget(t, "example", "https://example.com/health")
+			target.TimeoutInSec = 5
+			if err := store.Put(ctx, target); err != nil {
+				t.Fatalf("Failed to replace target: %v", err)
+			}
+			if err := store.Delete(ctx, "other"); err != nil {
+				t.Fatalf("Failed to delete target: %v", err)
+			}
+			if err := store.Delete(ctx, "missing"); err != nil {
+				t.Fatalf("Expected deleting a missing target to succeed, got %v", err)
+			}
+
+			targets, err := store.List(ctx)
+			if err != nil {
+				t.Fatalf("Failed to list targets: %v", err)
+			}
+			if len(targets) != 1 {
+				t.Fatalf("Expected one target, got %v", targets)
+			}
+			got := targets[0]
+			if got.ID != "example" || got.URLString != "https://example.com/health" || got.URL.Path != "/health" || got.TimeoutInSec != 5 {
+				t.Fatalf("Unexpected target: %+v", got)
+			}
+
+			err = store.Apply(ctx, []HealthTarget{mustTarget(t, "first", "https://first.example.com"), mustTarget(t, "second", "https://second.example.com")}, []string{"example"})
+			if err != nil {
+				t.Fatalf("Failed to apply targets: %v", err)
+			}
+			targets, err = store.List(ctx)
+			if err != nil {
+				t.Fatalf("Failed to list targets: %v", err)
+			}
+			if len(targets) != 2 || slices.ContainsFunc(targets, func(t HealthTarget) bool { return t.ID == "example" }) {
+				t.Fatalf("Expected the applied targets, got %v", targets)
+			}
+		})
+	}
+}
+
+func TestTargetStoresSkipUndecodableTargets(t *testing.T) {
+	ctx := context.Background()
+	stores := map[string]func(t *testing.T) TargetStore{
+		TargetStoreSQLite: func(t *testing.T) TargetStore {
+			store, err := NewSQLiteTargetStore(ctx, filepath.Join(t.TempDir(), "targets.db"))
+			if err != nil {
+				t.Fatalf("Failed to open SQLite store: %v", err)
+			}
+			if _, err := store.db.ExecContext(ctx, `INSERT INTO targets (id, data) VALUES ('broken', '{"id":')`); err != nil {
+				t.Fatalf("Failed to insert broken target: %v", err)
+			}
+			return store
+		},
+		TargetStoreRedis: func(t *testing.T) TargetStore {
+			server := miniredis.RunT(t)
+			store, err := NewRedisTargetStore(ctx, "redis://"+server.Addr(), "")
+			if err != nil {
+				t.Fatalf("Failed to open Redis store: %v", err)
+			}
+			server.HSet(defaultRedisTargetKey, "broken", `{"id":`)
+			return store
+		},
+	}
+
+	for name, open := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := open(t)
+			defer store.Close()
+			if err := store.Put(ctx, mustTarget(t, "example", "https://example.com")); err != nil {
+				t.Fatalf("Failed to put target: %v", err)
+			}
+
+			targets, err := store.List(ctx)
+			if err != nil {
+				t.Fatalf("Expected the broken target to be skipped, got %v", err)
+			}
+			if len(targets) != 1 || targets[0].ID != "example" {
+				t.Fatalf("Expected only the intact target, got %v", targets)
+			}
+		})
+	}
+}
+
+func TestSharedTargetStore(t *testing.T) {
+	server := miniredis.RunT(t)
+	newReplica := func() *HealthChecker {
+		store, err := NewRedisTargetStore(context.Background(), "redis://"+server.Addr(), "")
+		if err != nil {
+			t.Fatalf("Failed to open Redis store: %v", err)
+		}
+		t.Cleanup(func() { store.Close() })
+		hc, err := NewHealthChecker(time.Second, store)
+		if err != nil {
+			t.Fatalf("Failed to create health checker: %v", err)
+		}
+		return hc
+	}
+	first, second := newReplica(), newReplica()
+
+	if err := first.AddTarget(context.Background(), mustTarget(t, "example", "https://example.com")); err != nil {
+		t.Fatalf("Failed to add target: %v", err)
+	}
+	if err := second.Sync(context.Background()); err != nil {
+		t.Fatalf("Failed to sync: %v", err)
+	}
+	if ids := slices.Collect(maps.Keys(second.targets)); len(ids) != 1 || ids[0] != "example" {
+		t.Fatalf("Expected the target to be shared, got %v", ids)
+	}
+
+	if err := second.RemoveTarget(context.Background(), "example"); err != nil {
+		t.Fatalf("Failed to remove target: %v", err)
+	}
+	if err := first.Sync(context.Background()); err != nil {
+		t.Fatalf("Failed to sync: %v", err)
+	}
+	if len(first.targets) != 0 {
+		t.Fatalf("Expected the removal to be shared, got %v", first.targets)
+	}
+
+	// Stored targets with invalid IDs are ignored instead of being checked under an ambiguous key
+	if err := first.store.Put(context.Background(), mustTarget(t, "team/api", "https://example.com")); err != nil {
+		t.Fatalf("Failed to store target: %v", err)
+	}
+	if err := first.Sync(context.Background()); err != nil {
+		t.Fatalf("Failed to sync: %v", err)
+	}
+	if len(first.targets) != 0 || !first.invalid["team/api"] {
+		t.Fatalf("Expected the invalid target to be ignored, got %v", first.targets)
+	}
+}
+
+// blockingStore blocks the first List until release is closed, returning what was
+// stored before it was called
+type blockingStore struct {
+	TargetStore
+	listing chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (s *blockingStore) List(ctx context.Context) ([]HealthTarget, error) {
+	targets, err := s.TargetStore.List(ctx)
+	s.once.Do(func() {
+		close(s.listing)
+		<-s.release
+	})
+	return targets, err
+}
+
+func TestSyncDuringWrites(t *testing.T) {
+	hc, err := NewHealthChecker(time.Second, nil)
+	if err != nil {
+		t.Fatalf("Failed to create health checker: %v", err)
+	}
+	fileStore, err := NewFileTargetStore(filepath.Join(t.TempDir(), "targets.json"), false)
+	if err != nil {
+		t.Fatalf("Failed to open file store: %v", err)
+	}
+	t.Cleanup(func() { fileStore.Close() })
+	store := &blockingStore{TargetStore: fileStore, listing: make(chan struct{}), release: make(chan struct{})}
+	hc.store = store
+
+	synced := make(chan error)
+	go func() { synced <- hc.Sync(context.Background()) }()
+	<-store.listing
+
+	// Not blocked by the slow List, and not undone by its outdated result
+	if err := hc.AddTarget(context.Background(), mustTarget(t, "example", "https://example.com")); err != nil {
+		t.Fatalf("Failed to add target: %v", err)
+	}
+	close(store.release)
+	if err := <-synced; err != nil {
+		t.Fatalf("Failed to sync: %v", err)
+	}
+	if _, ok := hc.Target("example"); !ok {
+		t.Fatalf("Expected the target added during the sync to be kept")
+	}
+}
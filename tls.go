@@ -0,0 +1,114 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// TLSConfig serves the API over HTTPS. The files are re-read when they change, so
+// certificates can be rotated without a restart.
+type TLSConfig struct {
+	CertFile string `json:"certFile"`
+	KeyFile  string `json:"keyFile"`
+	// CA bundle client certificates are verified against, enables mutual TLS
+	ClientCAFile      string `json:"clientCAFile,omitempty"`
+	RequireClientCert bool   `json:"requireClientCert,omitempty"` // reject connections without a client certificate
+}
+
+// ClientCertConfig maps a client certificate to a role
+type ClientCertConfig struct {
+	CommonName string `json:"commonName"` // subject common name of the verified client certificate
+	Role       string `json:"role"`
+	Namespace  string `json:"namespace,omitempty"` // restricts the client to a namespace
+}
+
+// tlsFiles serves the certificate and client CAs of a TLSConfig and reloads them when
+// their files change. If a changed file is invalid, the previous ones stay in use.
+type tlsFiles struct {
+	config TLSConfig
+
+	mu       sync.Mutex
+	modTimes []time.Time
+	tls      *tls.Config
+}
+
+// newTLSFiles loads the files of config and fails if they are unusable
+func newTLSFiles(config TLSConfig) (*tlsFiles, error) {
+	f := &tlsFiles{config: config}
+	if _, err := f.load(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// TLSConfig returns a tls.Config for http.Server that picks up rotated files on new connections
+func (f *tlsFiles) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			config, err := f.load()
+			if err != nil {
+				slog.Error("failed to reload TLS files, keeping the previous ones", "error", err)
+			}
+			return config, nil
+		},
+	}
+}
+
+func (f *tlsFiles) load() (*tls.Config, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	paths := []string{f.config.CertFile, f.config.KeyFile}
+	if f.config.ClientCAFile != "" {
+		paths = append(paths, f.config.ClientCAFile)
+	}
+	modTimes := make([]time.Time, len(paths))
+	for i, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return f.tls, fmt.Errorf("failed to read TLS file: %w", err)
+		}
+		modTimes[i] = info.ModTime()
+	}
+	if f.tls != nil && slices.EqualFunc(modTimes, f.modTimes, time.Time.Equal) {
+		return f.tls, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(f.config.CertFile, f.config.KeyFile)
+	if err != nil {
+		return f.tls, fmt.Errorf("invalid TLS certificate %s: %w", f.config.CertFile, err)
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if f.config.ClientCAFile != "" {
+		data, err := os.ReadFile(f.config.ClientCAFile)
+		if err != nil {
+			return f.tls, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		config.ClientCAs = x509.NewCertPool()
+		if !config.ClientCAs.AppendCertsFromPEM(data) {
+			return f.tls, fmt.Errorf("client CA file %s contains no certificates", f.config.ClientCAFile)
+		}
+		// Without a required certificate, clients can still authenticate with API keys or tokens
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+		if f.config.RequireClientCert {
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	if f.tls != nil {
+		slog.Info("reloaded TLS files", "cert", f.config.CertFile)
+	}
+	f.tls = config
+	f.modTimes = modTimes
+	return f.tls, nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+// issueCert creates a certificate signed by parent, or a self-signed CA without a parent
+func issueCert(t *testing.T, serial int64, commonName string, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, key.Public(), signerKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCert{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (c *testCert) keyPair(t *testing.T) tls.Certificate {
+	t.Helper()
+	der, _ := x509.MarshalECPrivateKey(c.key)
+	pair, err := tls.X509KeyPair(c.pem, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("Failed to create key pair: %v", err)
+	}
+	return pair
+}
+
+func (c *testCert) write(t *testing.T, certFile, keyFile string, modTime time.Time) {
+	t.Helper()
+	der, _ := x509.MarshalECPrivateKey(c.key)
+	if err := os.WriteFile(certFile, c.pem, 0o644); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	// Set explicitly, rewrites within the file system's timestamp resolution would go unnoticed
+	for _, path := range []string{certFile, keyFile} {
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Failed to set modification time: %v", err)
+		}
+	}
+}
+
+func TestTLS(t *testing.T) {
+	dir := t.TempDir()
+	config := TLSConfig{
+		CertFile:     filepath.Join(dir, "server.pem"),
+		KeyFile:      filepath.Join(dir, "server-key.pem"),
+		ClientCAFile: filepath.Join(dir, "clients.pem"),
+	}
+	ca := issueCert(t, 1, "Doctor CA", nil)
+	if err := os.WriteFile(config.ClientCAFile, ca.pem, 0o644); err != nil {
+		t.Fatalf("Failed to write client CAs: %v", err)
+	}
+	issueCert(t, 2, "doctor", ca).write(t, config.CertFile, config.KeyFile, time.Now().Add(-time.Minute))
+
+	files, err := newTLSFiles(config)
+	if err != nil {
+		t.Fatalf("Failed to load TLS files: %v", err)
+	}
+	auth := mustAuthenticator(t, &AuthConfig{
+		APIKeys:            []APIKeyConfig{{Name: "wallboard", Key: "read-only-key-0123456789", Role: RoleReadOnly}},
+		ClientCertificates: []ClientCertConfig{{CommonName: "deploy-bot", Role: RoleOperator}},
+	})
+	checker, _ := NewHealthChecker(time.Second, nil)
+	router := http.NewServeMux()
+	HandlerWithOptions(NewServer(checker, NewHealthMonitor(checker, time.Hour, nil), nil), StdHTTPServerOptions{
+		BaseRouter:  router,
+		Middlewares: []MiddlewareFunc{auth.Middleware},
+	})
+	server := httptest.NewUnstartedServer(router)
+	server.TLS = files.TLSConfig()
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	send := func(t *testing.T, method, path string, certs []tls.Certificate, header http.Header) *http.Response {
+		t.Helper()
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{RootCAs: roots, Certificates: certs},
+			DisableKeepAlives: true,
+		}}
+		req, _ := http.NewRequest(method, server.URL+path, nil)
+		req.Header = header
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		certs  []tls.Certificate
+		header http.Header
+		status int
+	}{
+		{"Mapped client certificate", "DELETE", []tls.Certificate{issueCert(t, 3, "deploy-bot", ca).keyPair(t)}, nil, http.StatusNotFound},
+		{"Unmapped client certificate", "DELETE", []tls.Certificate{issueCert(t, 4, "intruder", ca).keyPair(t)}, nil, http.StatusUnauthorized},
+		{"API key without client certificate", "GET", nil, http.Header{"X-Api-Key": {"read-only-key-0123456789"}}, http.StatusOK},
+		{"No credentials", "GET", nil, nil, http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := "/targets"
+			if test.method == "DELETE" {
+				path = "/targets/example"
+			}
+			if resp := send(t, test.method, path, test.certs, test.header); resp.StatusCode != test.status {
+				t.Fatalf("Expected %d, got %d", test.status, resp.StatusCode)
+			}
+		})
+	}
+
+	t.Run("Rejects certificates of other CAs", func(t *testing.T) {
+		other := issueCert(t, 5, "deploy-bot", issueCert(t, 6, "Other CA", nil)).keyPair(t)
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs: roots,
+			// Sent even though the server doesn't accept its CA, which Certificates wouldn't do
+			GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) { return &other, nil },
+		}}}
+		if resp, err := client.Get(server.URL + "/targets"); err == nil {
+			resp.Body.Close()
+			t.Fatalf("Expected the handshake to fail, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("Reloads a rotated certificate", func(t *testing.T) {
+		issueCert(t, 7, "doctor", ca).write(t, config.CertFile, config.KeyFile, time.Now())
+		resp := send(t, "GET", "/targets", nil, http.Header{"X-Api-Key": {"read-only-key-0123456789"}})
+		if serial := resp.TLS.PeerCertificates[0].SerialNumber.Int64(); serial != 7 {
+			t.Fatalf("Expected the rotated certificate 7, got %d", serial)
+		}
+	})
+
+	t.Run("Keeps the certificate if the rotated one is invalid", func(t *testing.T) {
+		if err := os.WriteFile(config.CertFile, []byte("garbage"), 0o644); err != nil {
+			t.Fatalf("Failed to write certificate: %v", err)
+		}
+		_ = os.Chtimes(config.CertFile, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+		resp := send(t, "GET", "/targets", nil, http.Header{"X-Api-Key": {"read-only-key-0123456789"}})
+		if serial := resp.TLS.PeerCertificates[0].SerialNumber.Int64(); serial != 7 {
+			t.Fatalf("Expected the previous certificate 7, got %d", serial)
+		}
+	})
+}
@@ -7,3 +7,20 @@ func MapValues[K comparable, V any](m map[K]V) []V {
 	}
 	return values
 }
+
+// nonNil returns an empty slice instead of nil, so it is encoded as [] instead of null
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+// derefOrZero returns the value p points to or the zero value if p is nil
+func derefOrZero[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
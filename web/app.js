@@ -0,0 +1,327 @@
+"use strict";
+
+// The dashboard only uses the public API, with the credentials entered in the header.
+// They are kept in the browser's local storage.
+
+const state = {
+    apiKey: localStorage.getItem("doctor.apiKey") || "",
+    namespace: localStorage.getItem("doctor.namespace") || "",
+    targets: new Map(), // id -> {target, status, history}
+    events: null, // AbortController of the event stream
+    reload: null, // timeout of a pending reload of the targets
+};
+
+function headers(extra) {
+    const h = Object.assign({}, extra);
+    if (state.apiKey) h["X-API-Key"] = state.apiKey;
+    if (state.namespace) h["X-Namespace"] = state.namespace;
+    return h;
+}
+
+async function api(method, path, body) {
+    const options = { method, headers: headers() };
+    if (body !== undefined) {
+        options.headers["Content-Type"] = "application/json";
+        options.body = JSON.stringify(body);
+    }
+    const resp = await fetch(path, options);
+    if (!resp.ok) {
+        let message = resp.statusText;
+        try {
+            const error = await resp.json();
+            message = error.message || error.code || message;
+        } catch (e) {
+            // not a JSON error
+        }
+        throw new Error(`${method} ${path}: ${message}`);
+    }
+    return resp.status === 204 ? null : resp.json();
+}
+
+function showError(err) {
+    const el = document.getElementById("error");
+    el.textContent = err ? err.message : "";
+    el.hidden = !err;
+}
+
+function el(tag, attrs, ...children) {
+    const node = document.createElement(tag);
+    for (const [key, value] of Object.entries(attrs || {})) {
+        if (key.startsWith("on")) node.addEventListener(key.slice(2), value);
+        else node.setAttribute(key, value);
+    }
+    for (const child of children) {
+        if (child !== null && child !== undefined) node.append(child);
+    }
+    return node;
+}
+
+function formatAge(timestamp) {
+    const seconds = Math.max(0, (Date.now() - new Date(timestamp).getTime()) / 1000);
+    if (seconds < 60) return `${Math.round(seconds)}s`;
+    if (seconds < 3600) return `${Math.round(seconds / 60)}m`;
+    if (seconds < 86400) return `${Math.round(seconds / 3600)}h`;
+    return `${Math.round(seconds / 86400)}d`;
+}
+
+function sparkline(history) {
+    const width = 120, height = 24;
+    const svg = document.createElementNS("http://www.w3.org/2000/svg", "svg");
+    svg.setAttribute("class", "sparkline");
+    svg.setAttribute("width", width);
+    svg.setAttribute("height", height);
+    if (history.length < 2) return svg;
+
+    const max = Math.max(...history.map((h) => h.duration_seconds), 0.001);
+    const x = (i) => (i / (history.length - 1)) * (width - 4) + 2;
+    const y = (h) => height - 2 - (h.duration_seconds / max) * (height - 4);
+    const line = document.createElementNS(svg.namespaceURI, "polyline");
+    line.setAttribute("points", history.map((h, i) => `${x(i)},${y(h)}`).join(" "));
+    svg.append(line);
+    history.forEach((h, i) => {
+        if (h.healthy) return;
+        const dot = document.createElementNS(svg.namespaceURI, "circle");
+        dot.setAttribute("cx", x(i));
+        dot.setAttribute("cy", y(h));
+        dot.setAttribute("r", 2);
+        svg.append(dot);
+    });
+    return svg;
+}
+
+function renderTargets() {
+    const rows = [...state.targets.values()].sort((a, b) => a.target.id.localeCompare(b.target.id)).map(({ target, status, history }) => {
+        const health = status ? (status.healthy ? "healthy" : "unhealthy") : "unknown";
+        const labels = Object.entries(target.labels || {}).map(([k, v]) => el("span", { class: "label" }, `${k}=${v}`));
+        const actions = el("td", {},
+            el("button", { onclick: () => checkTarget(target.id), title: "Check now" }, "Check"));
+        if (target.source !== "config") {
+            actions.append(" ", el("button", { onclick: () => removeTarget(target.id) }, "Remove"));
+        }
+        return el("tr", {},
+            el("td", {}, el("span", { class: `dot ${health}`, title: status && status.error ? status.error : health })),
+            el("td", {}, target.id),
+            el("td", { class: "url", title: target.url }, target.url),
+            el("td", {}, ...labels),
+            el("td", {}, status ? `${Math.round(status.duration_seconds * 1000)} ms` : ""),
+            el("td", {}, sparkline(history)),
+            el("td", { class: "muted" }, status ? formatAge(status.last_change) : ""),
+            actions);
+    });
+    document.getElementById("targets").replaceChildren(...rows);
+}
+
+async function loadTargets() {
+    const [targets, statuses] = await Promise.all([api("GET", "/targets"), api("GET", "/status")]);
+    const byId = new Map(statuses.filter((s) => !s.pending).map((s) => [s.id, s]));
+    state.targets = new Map(targets.map((t) => [t.id, { target: t, status: byId.get(t.id), history: [] }]));
+    await Promise.all(targets.map(async (t) => {
+        state.targets.get(t.id).history = await api("GET", `/targets/${encodeURIComponent(t.id)}/history`);
+    }));
+    renderTargets();
+}
+
+async function loadIncidents() {
+    const incidents = await api("GET", "/incidents");
+    const items = incidents.map((i) => el("li", {},
+        el("span", { class: i.resolved_at ? "" : "open" }, i.removed ? "removed" : i.resolved_at ? "resolved" : "open"),
+        ` ${i.target_id} `,
+        el("span", { class: "muted" }, `${new Date(i.opened_at).toLocaleString()}`),
+        i.resolved_at ? el("span", { class: "muted" }, ` → ${new Date(i.resolved_at).toLocaleString()}`) : null,
+        i.silenced ? el("span", { class: "muted" }, " (silenced)") : null,
+        i.error ? el("div", { class: "muted" }, i.error) : null));
+    if (items.length === 0) items.push(el("li", { class: "muted" }, "No incidents"));
+    document.getElementById("incidents").replaceChildren(...items);
+}
+
+async function loadSilences() {
+    const silences = await api("GET", "/silences");
+    const items = silences.map((s) => {
+        const scope = [...(s.targets || []), ...(s.label || [])].join(", ") || "all targets";
+        return el("li", {},
+            `${scope}: ${s.comment} `,
+            el("span", { class: "muted" }, `until ${new Date(s.endsAt).toLocaleString()}${s.createdBy ? ` by ${s.createdBy}` : ""} `),
+            el("button", { onclick: () => endSilence(s.id) }, "End"));
+    });
+    if (items.length === 0) items.push(el("li", { class: "muted" }, "No silences"));
+    document.getElementById("silences").replaceChildren(...items);
+}
+
+async function checkTarget(id) {
+    try {
+        await api("POST", `/targets/${encodeURIComponent(id)}/check`);
+        showError(null);
+    } catch (err) {
+        showError(err);
+    }
+}
+
+async function removeTarget(id) {
+    if (!confirm(`Remove ${id}?`)) return;
+    try {
+        await api("DELETE", `/targets/${encodeURIComponent(id)}`);
+        state.targets.delete(id);
+        renderTargets();
+        showError(null);
+    } catch (err) {
+        showError(err);
+    }
+}
+
+async function endSilence(id) {
+    try {
+        await api("DELETE", `/silences/${id}`);
+        await loadSilences();
+        showError(null);
+    } catch (err) {
+        showError(err);
+    }
+}
+
+function splitList(value) {
+    return value.split(",").map((s) => s.trim()).filter((s) => s !== "");
+}
+
+async function register(event) {
+    event.preventDefault();
+    const form = event.target;
+    const fields = form.elements; // form.id would be the form's own id
+    const target = { id: fields.id.value.trim(), url: fields.url.value.trim() };
+    if (fields.timeoutInSec.value) target.timeoutInSec = Number(fields.timeoutInSec.value);
+    const labels = splitList(fields.labels.value);
+    if (labels.length > 0) {
+        target.labels = Object.fromEntries(labels.map((l) => {
+            const i = l.indexOf("=");
+            return i < 0 ? [l, ""] : [l.slice(0, i), l.slice(i + 1)];
+        }));
+    }
+    try {
+        const created = await api("POST", "/targets", target);
+        state.targets.set(created.id, { target: created, status: undefined, history: [] });
+        renderTargets();
+        form.reset();
+        showError(null);
+    } catch (err) {
+        showError(err);
+    }
+}
+
+async function silence(event) {
+    event.preventDefault();
+    const form = event.target;
+    const fields = form.elements;
+    const body = {
+        comment: fields.comment.value.trim(),
+        endsAt: new Date(Date.now() + Number(fields.hours.value) * 3600 * 1000).toISOString(),
+    };
+    const targets = splitList(fields.targets.value);
+    const label = splitList(fields.label.value);
+    if (targets.length > 0) body.targets = targets;
+    if (label.length > 0) body.label = label;
+    try {
+        await api("POST", "/silences", body);
+        await loadSilences();
+        form.reset();
+        showError(null);
+    } catch (err) {
+        showError(err);
+    }
+}
+
+function handleEvent(type, data) {
+    const entry = state.targets.get(data.id);
+    if (!entry) {
+        // Registered elsewhere, reloaded once for a burst of events
+        if (!state.reload) {
+            state.reload = setTimeout(() => {
+                state.reload = null;
+                loadTargets().catch(showError);
+            }, 1000);
+        }
+        return;
+    }
+    entry.status = data;
+    if (type === "check") {
+        entry.history.push({
+            timestamp: data.timestamp,
+            healthy: data.healthy,
+            status: data.status,
+            duration_seconds: data.duration_seconds,
+        });
+        if (entry.history.length > 60) entry.history.shift();
+    }
+    if (type === "incident") loadIncidents().catch(showError);
+    renderTargets();
+}
+
+// streamEvents reads /events with fetch instead of EventSource, which can't send the API key
+async function streamEvents() {
+    if (state.events) state.events.abort();
+    const controller = new AbortController();
+    state.events = controller;
+    const connection = document.getElementById("connection");
+
+    while (!controller.signal.aborted) {
+        try {
+            const resp = await fetch("/events", { headers: headers(), signal: controller.signal });
+            if (!resp.ok) throw new Error(`GET /events: ${resp.statusText}`);
+            connection.textContent = "live";
+            connection.classList.add("live");
+
+            const reader = resp.body.pipeThrough(new TextDecoderStream()).getReader();
+            let buffer = "";
+            for (;;) {
+                const { value, done } = await reader.read();
+                if (done) break;
+                buffer += value;
+                let end;
+                while ((end = buffer.indexOf("\n\n")) >= 0) {
+                    const message = buffer.slice(0, end);
+                    buffer = buffer.slice(end + 2);
+                    let type = "message", data = "";
+                    for (const line of message.split("\n")) {
+                        if (line.startsWith("event: ")) type = line.slice(7);
+                        else if (line.startsWith("data: ")) data += line.slice(6);
+                    }
+                    if (data) handleEvent(type, JSON.parse(data));
+                }
+            }
+        } catch (err) {
+            if (controller.signal.aborted) return;
+        }
+        connection.textContent = "reconnecting";
+        connection.classList.remove("live");
+        await new Promise((resolve) => setTimeout(resolve, 3000));
+        await loadTargets().catch(showError);
+    }
+}
+
+async function connect() {
+    try {
+        await Promise.all([loadTargets(), loadIncidents(), loadSilences()]);
+        showError(null);
+        streamEvents();
+    } catch (err) {
+        showError(err);
+    }
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+    const credentials = document.getElementById("credentials");
+    credentials.elements.apiKey.value = state.apiKey;
+    credentials.elements.namespace.value = state.namespace;
+    credentials.addEventListener("submit", (event) => {
+        event.preventDefault();
+        state.apiKey = credentials.elements.apiKey.value.trim();
+        state.namespace = credentials.elements.namespace.value.trim();
+        localStorage.setItem("doctor.apiKey", state.apiKey);
+        localStorage.setItem("doctor.namespace", state.namespace);
+        connect();
+    });
+    document.getElementById("register").addEventListener("submit", register);
+    document.getElementById("silence").addEventListener("submit", silence);
+
+    // The "since" column is relative to now
+    setInterval(renderTargets, 30000);
+    connect();
+});